@@ -0,0 +1,3 @@
+package accounts
+
+var Pbkdf2 = pbkdf2
@@ -0,0 +1,182 @@
+package accounts
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+const (
+	saltSize       = 16
+	hashIterations = 100000
+	maxNameLength  = 20
+)
+
+var (
+	ErrInvalidName        = errors.New("names must be 1 to 20 letters, digits, '-' or '_'")
+	ErrEmptyPassword      = errors.New("a password is required")
+	ErrNameTaken          = errors.New("that name is already registered")
+	ErrInvalidCredentials = errors.New("unknown name or wrong password")
+)
+
+var unknownUserSalt = make([]byte, saltSize)
+
+type User struct {
+	Name       string
+	Salt       []byte
+	Hash       []byte
+	Iterations int
+}
+
+type UserStore struct {
+	lock  sync.Mutex
+	path  string
+	users map[string]User
+}
+
+// pbkdf2 derives a single SHA-256 sized key as described in RFC 8018.
+func pbkdf2(password []byte, salt []byte, iterations int) []byte {
+	mac := hmac.New(sha256.New, password)
+
+	block := make([]byte, 4)
+	binary.BigEndian.PutUint32(block, 1)
+
+	mac.Write(salt)
+	mac.Write(block)
+	u := mac.Sum(nil)
+
+	result := make([]byte, len(u))
+	copy(result, u)
+
+	for i := 1; i < iterations; i++ {
+		mac.Reset()
+		mac.Write(u)
+		u = mac.Sum(u[:0])
+
+		for j := range result {
+			result[j] ^= u[j]
+		}
+	}
+
+	return result
+}
+
+func validName(name string) bool {
+	if len(name) == 0 || len(name) > maxNameLength {
+		return false
+	}
+
+	for _, r := range name {
+		if !(unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_') {
+			return false
+		}
+	}
+
+	return true
+}
+
+func key(name string) string {
+	return strings.ToLower(name)
+}
+
+func (store *UserStore) save() error {
+	data, err := json.MarshalIndent(store.users, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	temporary := store.path + ".tmp"
+	err = ioutil.WriteFile(temporary, data, 0600)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(temporary, store.path)
+}
+
+func (store *UserStore) Register(name string, password string) (User, error) {
+	if !validName(name) {
+		return User{}, ErrInvalidName
+	}
+	if password == "" {
+		return User{}, ErrEmptyPassword
+	}
+
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	if _, found := store.users[key(name)]; found {
+		return User{}, ErrNameTaken
+	}
+
+	salt := make([]byte, saltSize)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return User{}, err
+	}
+
+	user := User{
+		Name:       name,
+		Salt:       salt,
+		Hash:       pbkdf2([]byte(password), salt, hashIterations),
+		Iterations: hashIterations,
+	}
+
+	store.users[key(name)] = user
+	err = store.save()
+	if err != nil {
+		delete(store.users, key(name))
+		return User{}, err
+	}
+
+	return user, nil
+}
+
+func (store *UserStore) Authenticate(name string, password string) (User, error) {
+	store.lock.Lock()
+	user, found := store.users[key(name)]
+	store.lock.Unlock()
+
+	// Unknown names cost as much as wrong passwords, otherwise the response
+	// time would tell which names are registered.
+	if !found {
+		pbkdf2([]byte(password), unknownUserSalt, hashIterations)
+		return User{}, ErrInvalidCredentials
+	}
+
+	hash := pbkdf2([]byte(password), user.Salt, user.Iterations)
+	if !hmac.Equal(hash, user.Hash) {
+		return User{}, ErrInvalidCredentials
+	}
+
+	return user, nil
+}
+
+func NewUserStore(path string) (*UserStore, error) {
+	users := make(map[string]User)
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	if err == nil && len(data) > 0 {
+		err = json.Unmarshal(data, &users)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &UserStore{
+		path:  path,
+		users: users,
+	}, nil
+}
@@ -0,0 +1,142 @@
+package accounts_test
+
+import (
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reversi/accounts"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestStore(t *testing.T) (*accounts.UserStore, string) {
+	directory, err := ioutil.TempDir("", "accounts")
+	if err != nil {
+		t.Fatalf("failed to create a temporary directory: %s", err.Error())
+	}
+	t.Cleanup(func() { os.RemoveAll(directory) })
+
+	path := filepath.Join(directory, "users.json")
+	store, err := accounts.NewUserStore(path)
+	if err != nil {
+		t.Fatalf("failed to create the store: %s", err.Error())
+	}
+
+	return store, path
+}
+
+func Test_RegisteredUser_canAuthenticate(t *testing.T) {
+	store, _ := newTestStore(t)
+
+	_, err := store.Register("ada", "secret")
+	if err != nil {
+		t.Fatalf("Registration should have succeeded: %s", err.Error())
+	}
+
+	user, err := store.Authenticate("ada", "secret")
+	if err != nil {
+		t.Errorf("Authentication should have succeeded: %s", err.Error())
+	}
+	if user.Name != "ada" {
+		t.Errorf("Expected the user ada but got %s", user.Name)
+	}
+}
+
+func Test_WrongPassword_isRejected(t *testing.T) {
+	store, _ := newTestStore(t)
+	store.Register("ada", "secret")
+
+	_, err := store.Authenticate("ada", "guess")
+	if err != accounts.ErrInvalidCredentials {
+		t.Errorf("Expected invalid credentials but got %v", err)
+	}
+
+	_, err = store.Authenticate("bob", "secret")
+	if err != accounts.ErrInvalidCredentials {
+		t.Errorf("Expected invalid credentials for an unknown user but got %v", err)
+	}
+}
+
+func Test_Names_areUniqueIgnoringCase(t *testing.T) {
+	store, _ := newTestStore(t)
+	store.Register("ada", "secret")
+
+	_, err := store.Register("ADA", "other")
+	if err != accounts.ErrNameTaken {
+		t.Errorf("Expected the name to be taken but got %v", err)
+	}
+}
+
+func Test_InvalidNames_areRejected(t *testing.T) {
+	store, _ := newTestStore(t)
+
+	for _, name := range []string{"", "has space", "a-name-that-is-far-too-long"} {
+		_, err := store.Register(name, "secret")
+		if err != accounts.ErrInvalidName {
+			t.Errorf("Expected %q to be rejected but got %v", name, err)
+		}
+	}
+}
+
+func Test_Users_arePersistedWithoutThePassword(t *testing.T) {
+	store, path := newTestStore(t)
+	store.Register("ada", "secret")
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read the store: %s", err.Error())
+	}
+	if strings.Contains(string(data), "secret") {
+		t.Fatal("The password should not be stored in plain text")
+	}
+
+	reloaded, err := accounts.NewUserStore(path)
+	if err != nil {
+		t.Fatalf("failed to reload the store: %s", err.Error())
+	}
+
+	_, err = reloaded.Authenticate("ada", "secret")
+	if err != nil {
+		t.Errorf("The reloaded store should authenticate ada: %s", err.Error())
+	}
+}
+
+func Test_Pbkdf2_matchesThePublishedVectors(t *testing.T) {
+	vectors := []struct {
+		password   string
+		salt       string
+		iterations int
+		expected   string
+	}{
+		{"password", "salt", 1, "120fb6cffcf8b32c43e7225256c4f837a86548c92ccc35480805987cb70be17b"},
+		{"password", "salt", 2, "ae4d0c95af6b46d32d0adff928f06dd02a303f8ef3c251dfd6e2d85a95474c43"},
+		{"password", "salt", 4096, "c5e478d59288c841aa530db6845c4c8d962893a001ce4e11a4963873aa98134a"},
+		{"passwordPASSWORDpassword", "saltSALTsaltSALTsaltSALTsaltSALTsalt", 4096, "348c89dbcbd32b2f32d814b8116e84cf2b17347ebc1800181c4e2a1fb8dd53e1"},
+	}
+
+	for _, vector := range vectors {
+		actual := hex.EncodeToString(accounts.Pbkdf2([]byte(vector.password), []byte(vector.salt), vector.iterations))
+		if actual != vector.expected {
+			t.Errorf("%s with %s and %d iterations should derive %s, instead got %s", vector.password, vector.salt, vector.iterations, vector.expected, actual)
+		}
+	}
+}
+
+func Test_UnknownNames_takeAsLongAsWrongPasswords(t *testing.T) {
+	store, _ := newTestStore(t)
+	store.Register("ada", "secret")
+
+	started := time.Now()
+	store.Authenticate("ada", "guess")
+	wrongPassword := time.Since(started)
+
+	started = time.Now()
+	store.Authenticate("bob", "guess")
+	unknownName := time.Since(started)
+
+	if unknownName < wrongPassword/2 {
+		t.Errorf("An unknown name was answered in %s, a wrong password in %s", unknownName, wrongPassword)
+	}
+}
@@ -0,0 +1,294 @@
+package analysis
+
+import (
+	"math"
+	"reversi/core"
+	"reversi/engine"
+)
+
+const (
+	DefaultDepth        = 8
+	DefaultExactEmpties = 14
+	minimumDepth        = 2
+	// accuracyHalving is the loss in discs that halves a move's accuracy.
+	accuracyHalving = 4.0
+)
+
+var ErrIllegalMove = core.ErrIllegalMove
+
+type Classification string
+
+const (
+	BEST       Classification = "BEST"
+	GOOD       Classification = "GOOD"
+	INACCURACY Classification = "INACCURACY"
+	MISTAKE    Classification = "MISTAKE"
+	BLUNDER    Classification = "BLUNDER"
+)
+
+// thresholds are the smallest losses in discs for each classification.
+var thresholds = []struct {
+	loss           float64
+	classification Classification
+}{
+	{8, BLUNDER},
+	{4, MISTAKE},
+	{2, INACCURACY},
+	{0, GOOD},
+}
+
+type Options struct {
+	// Depth is how far every position is searched, DefaultDepth when 0.
+	Depth int
+	// ExactEmpties is the number of empty squares from which positions are
+	// solved exactly instead.
+	ExactEmpties int
+	// Workers are the threads each search uses, every core when 0.
+	Workers int
+	// Alternatives is how many of the best moves are listed with their lines
+	// for each position that is not solved exactly, none when 0.
+	Alternatives int
+}
+
+// Alternative is one of the best moves of a position, scored in discs for
+// the side to move, with the line the search expects to follow.
+type Alternative struct {
+	Move  core.Coordinate
+	Score float64
+	Line  []core.Coordinate
+}
+
+// MoveAnalysis scores the move played and the best move in discs from the
+// point of view of the side that moved.
+type MoveAnalysis struct {
+	Number         int
+	Side           core.Player
+	Played         core.Coordinate
+	Best           core.Coordinate
+	PlayedScore    float64
+	BestScore      float64
+	Loss           float64
+	Exact          bool
+	Classification Classification
+	Alternatives   []Alternative
+}
+
+type PlayerSummary struct {
+	Moves        int
+	AverageLoss  float64
+	Accuracy     float64
+	Inaccuracies int
+	Mistakes     int
+	Blunders     int
+}
+
+type Report struct {
+	Moves   []MoveAnalysis
+	Players map[core.Player]PlayerSummary
+}
+
+func Classify(loss float64, playedTheBest bool) Classification {
+	if playedTheBest || loss <= 0 {
+		return BEST
+	}
+
+	for _, threshold := range thresholds {
+		if loss >= threshold.loss {
+			return threshold.classification
+		}
+	}
+
+	return GOOD
+}
+
+// Accuracy of a move is 100 for the best move and halves with every
+// accuracyHalving discs lost.
+func Accuracy(loss float64) float64 {
+	return 100 * math.Pow(2, -loss/accuracyHalving)
+}
+
+type analyser struct {
+	searcher     *engine.ParallelSearcher
+	alternatives *engine.Searcher
+	options      Options
+}
+
+// score is the value of the position in discs for the side to move, and the
+// move that gets it.
+func (analyser *analyser) score(gameState core.GameState, depth int) (float64, core.Coordinate, bool, error) {
+	if len(gameState.Used) >= 64-analyser.options.ExactEmpties {
+		solution, err := engine.Solve(gameState, engine.EndgameLimits{Mode: engine.EXACT})
+		if err != nil {
+			return 0, core.Coordinate{}, false, err
+		}
+
+		return float64(solution.Margin), solution.Move, true, nil
+	}
+
+	result, err := analyser.searcher.BestMove(gameState, engine.Limits{Depth: depth})
+	if err != nil {
+		return 0, core.Coordinate{}, false, err
+	}
+
+	_, exact := engine.FinalMargin(result.Score)
+	return engine.ScoreInDiscs(result.Score), result.Move, exact, nil
+}
+
+// scoreAfter is the value of the position after the move for the side that
+// played it, the side to move may be the same one when the other has to
+// pass.
+func (analyser *analyser) scoreAfter(after core.GameState, side core.Player) (float64, bool, error) {
+	if after.IsOver() {
+		return float64(after.DiscCount(side) - after.DiscCount(side.Opposite())), true, nil
+	}
+
+	score, _, exact, err := analyser.score(after, analyser.options.Depth-1)
+	if after.PlayerTurn != side {
+		score = -score
+	}
+
+	return score, exact, err
+}
+
+func (analyser *analyser) alternativesIn(gameState core.GameState) ([]Alternative, error) {
+	if analyser.options.Alternatives <= 0 || len(gameState.Used) >= 64-analyser.options.ExactEmpties {
+		return nil, nil
+	}
+
+	results, err := analyser.alternatives.MultiPV(gameState, engine.Limits{Depth: analyser.options.Depth}, analyser.options.Alternatives)
+	if err != nil {
+		return nil, err
+	}
+
+	alternatives := []Alternative{}
+	for _, result := range results {
+		alternatives = append(alternatives, Alternative{
+			Move:  result.Move,
+			Score: engine.ScoreInDiscs(result.Score),
+			Line:  result.PrincipalVariation,
+		})
+	}
+	return alternatives, nil
+}
+
+func (analyser *analyser) analyse(number int, before core.GameState, after core.GameState, played core.Coordinate) (MoveAnalysis, error) {
+	side := before.PlayerTurn
+
+	alternatives, err := analyser.alternativesIn(before)
+	if err != nil {
+		return MoveAnalysis{}, err
+	}
+
+	// The best of the alternatives is the best move, so the two agree.
+	var bestScore float64
+	var best core.Coordinate
+	var exact bool
+	if len(alternatives) > 0 {
+		bestScore, best = alternatives[0].Score, alternatives[0].Move
+	} else {
+		bestScore, best, exact, err = analyser.score(before, analyser.options.Depth)
+		if err != nil {
+			return MoveAnalysis{}, err
+		}
+	}
+
+	playedScore := bestScore
+	if played != best {
+		var playedExact bool
+		playedScore, playedExact, err = analyser.scoreAfter(after, side)
+		if err != nil {
+			return MoveAnalysis{}, err
+		}
+		exact = exact && playedExact
+	}
+
+	// A shallower search of the move played can see further than the search
+	// that preferred another move, then the move played counts as the best.
+	if playedScore > bestScore {
+		best, bestScore = played, playedScore
+	}
+	loss := bestScore - playedScore
+
+	return MoveAnalysis{
+		Number:         number,
+		Side:           side,
+		Played:         played,
+		Best:           best,
+		PlayedScore:    playedScore,
+		BestScore:      bestScore,
+		Loss:           loss,
+		Exact:          exact,
+		Classification: Classify(loss, played == best),
+		Alternatives:   alternatives,
+	}, nil
+}
+
+func summarise(moves []MoveAnalysis) map[core.Player]PlayerSummary {
+	summaries := map[core.Player]PlayerSummary{core.BLACK: {}, core.WHITE: {}}
+
+	for _, move := range moves {
+		summary := summaries[move.Side]
+		summary.Moves++
+		summary.AverageLoss += move.Loss
+		summary.Accuracy += Accuracy(move.Loss)
+
+		switch move.Classification {
+		case INACCURACY:
+			summary.Inaccuracies++
+		case MISTAKE:
+			summary.Mistakes++
+		case BLUNDER:
+			summary.Blunders++
+		}
+
+		summaries[move.Side] = summary
+	}
+
+	for side, summary := range summaries {
+		if summary.Moves > 0 {
+			summary.AverageLoss /= float64(summary.Moves)
+			summary.Accuracy /= float64(summary.Moves)
+		}
+		summaries[side] = summary
+	}
+
+	return summaries
+}
+
+// AnalyseGame replays the moves and scores each one against the best move in
+// its position. progress, when given, is told about each move as it is done.
+func AnalyseGame(moves []core.Coordinate, options Options, progress func(MoveAnalysis)) (Report, error) {
+	if options.Depth <= 0 {
+		options.Depth = DefaultDepth
+	}
+	if options.Depth < minimumDepth {
+		options.Depth = minimumDepth
+	}
+
+	states, err := core.Replay(core.StartingPosition(), moves)
+	if err != nil {
+		return Report{}, err
+	}
+
+	analyser := &analyser{
+		searcher:     engine.NewParallelSearcher(engine.NewWeightedSquareEvaluator(), options.Workers),
+		alternatives: engine.NewSearcher(engine.NewWeightedSquareEvaluator()),
+		options:      options,
+	}
+
+	report := Report{Moves: []MoveAnalysis{}}
+	for i, move := range moves {
+		analysis, err := analyser.analyse(i+1, states[i], states[i+1], move)
+		if err != nil {
+			return Report{}, err
+		}
+
+		report.Moves = append(report.Moves, analysis)
+		if progress != nil {
+			progress(analysis)
+		}
+	}
+
+	report.Players = summarise(report.Moves)
+	return report, nil
+}
@@ -0,0 +1,149 @@
+package analysis_test
+
+import (
+	"errors"
+	"reversi/analysis"
+	"reversi/core"
+	"reversi/engine"
+	"testing"
+)
+
+var wholeGame = []core.Coordinate{
+	{X: 2, Y: 4}, {X: 2, Y: 5}, {X: 2, Y: 6}, {X: 1, Y: 4}, {X: 0, Y: 4}, {X: 4, Y: 5},
+	{X: 5, Y: 2}, {X: 4, Y: 2}, {X: 3, Y: 2}, {X: 2, Y: 1}, {X: 3, Y: 1}, {X: 4, Y: 1},
+	{X: 3, Y: 0}, {X: 1, Y: 5}, {X: 4, Y: 6}, {X: 2, Y: 7}, {X: 3, Y: 6}, {X: 1, Y: 3},
+	{X: 0, Y: 5}, {X: 3, Y: 5}, {X: 0, Y: 2}, {X: 1, Y: 2}, {X: 2, Y: 3}, {X: 2, Y: 2},
+	{X: 3, Y: 7}, {X: 0, Y: 3}, {X: 2, Y: 0}, {X: 6, Y: 2}, {X: 7, Y: 2}, {X: 5, Y: 3},
+	{X: 6, Y: 4}, {X: 5, Y: 4}, {X: 4, Y: 7}, {X: 7, Y: 4}, {X: 7, Y: 5}, {X: 5, Y: 7},
+	{X: 7, Y: 3}, {X: 6, Y: 3}, {X: 5, Y: 6}, {X: 5, Y: 5}, {X: 6, Y: 5}, {X: 5, Y: 0},
+	{X: 5, Y: 1}, {X: 4, Y: 0}, {X: 6, Y: 0}, {X: 1, Y: 7}, {X: 1, Y: 1}, {X: 1, Y: 0},
+	{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 6}, {X: 0, Y: 7}, {X: 0, Y: 6}, {X: 7, Y: 6},
+	{X: 7, Y: 7}, {X: 6, Y: 6}, {X: 6, Y: 7}, {X: 7, Y: 1}, {X: 6, Y: 1}, {X: 7, Y: 0},
+}
+
+func Test_Classify_usesTheDiscsLost(t *testing.T) {
+	cases := []struct {
+		loss     float64
+		best     bool
+		expected analysis.Classification
+	}{
+		{0, true, analysis.BEST},
+		{0, false, analysis.BEST},
+		{1, false, analysis.GOOD},
+		{2, false, analysis.INACCURACY},
+		{5, false, analysis.MISTAKE},
+		{8, false, analysis.BLUNDER},
+		{30, false, analysis.BLUNDER},
+	}
+
+	for _, c := range cases {
+		if analysis.Classify(c.loss, c.best) != c.expected {
+			t.Errorf("Expected losing %.0f discs to be %s, instead got %s", c.loss, c.expected, analysis.Classify(c.loss, c.best))
+		}
+	}
+}
+
+func Test_Accuracy_halvesEveryFourDiscs(t *testing.T) {
+	if analysis.Accuracy(0) != 100 || analysis.Accuracy(4) != 50 || analysis.Accuracy(8) != 25 {
+		t.Errorf("Expected 100, 50 and 25, instead got %f, %f and %f", analysis.Accuracy(0), analysis.Accuracy(4), analysis.Accuracy(8))
+	}
+}
+
+func Test_AnalyseGame_scoresEveryMove(t *testing.T) {
+	progress := 0
+	report, err := analysis.AnalyseGame(wholeGame, analysis.Options{Depth: 3, ExactEmpties: 12}, func(analysis.MoveAnalysis) { progress++ })
+	if err != nil {
+		t.Fatalf("Expected the game to be analysed, instead got %s", err.Error())
+	}
+
+	if len(report.Moves) != len(wholeGame) || progress != len(wholeGame) {
+		t.Fatalf("Expected %d moves, instead got %d with %d reported", len(wholeGame), len(report.Moves), progress)
+	}
+
+	for _, move := range report.Moves {
+		if move.Loss < 0 || move.BestScore < move.PlayedScore || move.Loss != move.BestScore-move.PlayedScore {
+			t.Errorf("Move %d: the best move should score at least as much as the one played, got %+v", move.Number, move)
+		}
+		if move.Number > len(wholeGame)-12 && !move.Exact {
+			t.Errorf("Move %d should have been solved exactly", move.Number)
+		}
+	}
+
+	players := report.Players
+	if players[core.BLACK].Moves+players[core.WHITE].Moves != len(wholeGame) {
+		t.Errorf("Expected every move to count for one of the players, instead got %+v", players)
+	}
+	for side, summary := range players {
+		if summary.Accuracy <= 0 || summary.Accuracy > 100 {
+			t.Errorf("Expected the accuracy of %s to be a percentage, instead got %f", side, summary.Accuracy)
+		}
+	}
+}
+
+func stateAfter(moves []core.Coordinate) core.GameState {
+	states, err := core.Replay(core.StartingPosition(), moves)
+	if err != nil {
+		panic(err)
+	}
+
+	return states[len(states)-1]
+}
+
+func Test_AnalyseGame_nearTheEnd_reportsTheExactScores(t *testing.T) {
+	report, err := analysis.AnalyseGame(wholeGame, analysis.Options{Depth: 2, ExactEmpties: 12}, nil)
+	if err != nil {
+		t.Fatalf("Expected the game to be analysed, instead got %s", err.Error())
+	}
+
+	for i := 50; i < len(wholeGame); i++ {
+		solution, err := engine.Solve(stateAfter(wholeGame[:i]), engine.EndgameLimits{Mode: engine.EXACT})
+		if err != nil {
+			t.Fatalf("failed to solve after %d moves: %s", i, err.Error())
+		}
+
+		move := report.Moves[i]
+		if move.BestScore != float64(solution.Margin) {
+			t.Errorf("Move %d: expected the best move to score %d, instead got %f", move.Number, solution.Margin, move.BestScore)
+		}
+	}
+
+	// The last move fills the board, there is nothing to choose.
+	last := report.Moves[len(report.Moves)-1]
+	if last.Loss != 0 || last.Classification != analysis.BEST {
+		t.Errorf("The only move left cannot lose anything, got %+v", last)
+	}
+}
+
+func Test_AnalyseGame_withAnIllegalMove_fails(t *testing.T) {
+	_, err := analysis.AnalyseGame([]core.Coordinate{{X: 0, Y: 0}}, analysis.Options{}, nil)
+	if !errors.Is(err, analysis.ErrIllegalMove) {
+		t.Errorf("Expected an illegal move error, instead got %v", err)
+	}
+}
+
+func Test_AnalyseGame_withAlternatives_listsTheBestMovesBeforeTheEndgame(t *testing.T) {
+	report, err := analysis.AnalyseGame(wholeGame[:30], analysis.Options{Depth: 3, ExactEmpties: 12, Alternatives: 3}, nil)
+	if err != nil {
+		t.Fatalf("Expected the game to be analysed, instead got %s", err.Error())
+	}
+
+	for i, move := range report.Moves {
+		before := stateAfter(wholeGame[:i])
+		expected := len(before.MoveOptions())
+		if expected > 3 {
+			expected = 3
+		}
+
+		if len(move.Alternatives) != expected {
+			t.Fatalf("Move %d: expected %d alternatives, instead got %d", move.Number, expected, len(move.Alternatives))
+		}
+		for j, alternative := range move.Alternatives {
+			if !before.MoveOptions()[alternative.Move] || alternative.Line[0] != alternative.Move {
+				t.Errorf("Move %d: (%d, %d) is not a legal alternative with its line", move.Number, alternative.Move.X, alternative.Move.Y)
+			}
+			if j > 0 && alternative.Score > move.Alternatives[j-1].Score {
+				t.Errorf("Move %d: the alternatives should be listed from the best", move.Number)
+			}
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package arena
+
+import "math"
+
+// confidence95 is the number of standard errors either side of the mean that
+// cover 95% of a normal distribution.
+const confidence95 = 1.959964
+
+type Tally struct {
+	Wins   int
+	Draws  int
+	Losses int
+}
+
+func (tally Tally) Games() int {
+	return tally.Wins + tally.Draws + tally.Losses
+}
+
+// Score is the share of the points won, a draw is half a point.
+func (tally Tally) Score() float64 {
+	if tally.Games() == 0 {
+		return 0.5
+	}
+
+	return (float64(tally.Wins) + float64(tally.Draws)/2) / float64(tally.Games())
+}
+
+// variance is the variance of the points won in a single game.
+func (tally Tally) variance() float64 {
+	games := float64(tally.Games())
+	if games == 0 {
+		return 0
+	}
+
+	score := tally.Score()
+	return (float64(tally.Wins)*math.Pow(1-score, 2) +
+		float64(tally.Draws)*math.Pow(0.5-score, 2) +
+		float64(tally.Losses)*math.Pow(score, 2)) / games
+}
+
+// EloFromScore is the rating difference that makes the score expected, it is
+// infinite for a score of 0 or 1.
+func EloFromScore(score float64) float64 {
+	if score <= 0 {
+		return math.Inf(-1)
+	}
+	if score >= 1 {
+		return math.Inf(1)
+	}
+
+	return -400 * math.Log10(1/score-1)
+}
+
+func ExpectedScore(elo float64) float64 {
+	return 1 / (1 + math.Pow(10, -elo/400))
+}
+
+// Elo estimates the rating difference the tally shows, with the margin of a
+// 95% confidence interval around it.
+func (tally Tally) Elo() (float64, float64) {
+	score := tally.Score()
+	elo := EloFromScore(score)
+
+	if tally.Games() == 0 || math.IsInf(elo, 0) {
+		return elo, math.Inf(1)
+	}
+
+	standardError := math.Sqrt(tally.variance() / float64(tally.Games()))
+	low := EloFromScore(score - confidence95*standardError)
+	high := EloFromScore(score + confidence95*standardError)
+
+	return elo, (high - low) / 2
+}
+
+type SPRTDecision string
+
+const (
+	CONTINUE SPRTDecision = "CONTINUE"
+	// ACCEPT_H0 means the difference is no more than Elo0.
+	ACCEPT_H0 SPRTDecision = "ACCEPT_H0"
+	// ACCEPT_H1 means the difference is at least Elo1.
+	ACCEPT_H1 SPRTDecision = "ACCEPT_H1"
+)
+
+// SPRT tests whether the first player is Elo0 or Elo1 stronger than the
+// second, with Alpha the chance of wrongly accepting H1 and Beta the chance
+// of wrongly accepting H0.
+type SPRT struct {
+	Elo0  float64
+	Elo1  float64
+	Alpha float64
+	Beta  float64
+}
+
+func (sprt SPRT) Bounds() (float64, float64) {
+	return math.Log(sprt.Beta / (1 - sprt.Alpha)), math.Log((1 - sprt.Beta) / sprt.Alpha)
+}
+
+// LogLikelihoodRatio uses the normal approximation of the generalised SPRT,
+// comparing the score expected under each hypothesis with the one observed.
+func (sprt SPRT) LogLikelihoodRatio(tally Tally) float64 {
+	if tally.Games() == 0 {
+		return 0
+	}
+
+	variance := tally.variance()
+	if variance == 0 {
+		// Every game ended the same way, counting a win and a loss as well
+		// keeps a handful of games from looking conclusive.
+		variance = Tally{Wins: tally.Wins + 1, Draws: tally.Draws, Losses: tally.Losses + 1}.variance()
+	}
+
+	score0 := ExpectedScore(sprt.Elo0)
+	score1 := ExpectedScore(sprt.Elo1)
+
+	return (score1 - score0) * (2*tally.Score() - score0 - score1) * float64(tally.Games()) / (2 * variance)
+}
+
+func (sprt SPRT) Decide(tally Tally) SPRTDecision {
+	lower, upper := sprt.Bounds()
+	ratio := sprt.LogLikelihoodRatio(tally)
+
+	if ratio >= upper {
+		return ACCEPT_H1
+	}
+	if ratio <= lower {
+		return ACCEPT_H0
+	}
+
+	return CONTINUE
+}
@@ -0,0 +1,62 @@
+package arena_test
+
+import (
+	"math"
+	"reversi/arena"
+	"testing"
+)
+
+func Test_Elo_ofAThreeQuarterScore_isAbout191(t *testing.T) {
+	elo, margin := arena.Tally{Wins: 60, Draws: 30, Losses: 10}.Elo()
+
+	if math.Abs(elo-190.85) > 0.01 {
+		t.Errorf("Expected about 190.85, instead got %f", elo)
+	}
+	if margin <= 0 || math.IsInf(margin, 0) {
+		t.Errorf("Expected a finite margin, instead got %f", margin)
+	}
+}
+
+func Test_EloMargin_shrinksWithMoreGames(t *testing.T) {
+	_, few := arena.Tally{Wins: 6, Draws: 3, Losses: 1}.Elo()
+	_, many := arena.Tally{Wins: 600, Draws: 300, Losses: 100}.Elo()
+
+	if many >= few/5 {
+		t.Errorf("A hundred times the games should give a tenth of the margin, instead got %f and %f", few, many)
+	}
+}
+
+func Test_EloFromScore_isTheInverseOfTheExpectedScore(t *testing.T) {
+	for _, elo := range []float64{-400, -35, 0, 12.5, 250} {
+		if math.Abs(arena.EloFromScore(arena.ExpectedScore(elo))-elo) > 1e-9 {
+			t.Errorf("Expected %f back", elo)
+		}
+	}
+
+	if !math.IsInf(arena.EloFromScore(1), 1) || !math.IsInf(arena.EloFromScore(0), -1) {
+		t.Error("Winning or losing every game should be an infinite difference")
+	}
+}
+
+func Test_SPRT_decidesOnlyWhenTheEvidenceIsClear(t *testing.T) {
+	sprt := arena.SPRT{Elo0: 0, Elo1: 50, Alpha: 0.05, Beta: 0.05}
+
+	cases := []struct {
+		tally    arena.Tally
+		expected arena.SPRTDecision
+	}{
+		{arena.Tally{Wins: 6, Draws: 2, Losses: 4}, arena.CONTINUE},
+		{arena.Tally{Wins: 300, Draws: 50, Losses: 150}, arena.ACCEPT_H1},
+		{arena.Tally{Wins: 200, Draws: 50, Losses: 250}, arena.ACCEPT_H0},
+		{arena.Tally{Wins: 20}, arena.ACCEPT_H1},
+		{arena.Tally{Wins: 3}, arena.CONTINUE},
+		{arena.Tally{Draws: 40}, arena.ACCEPT_H0},
+	}
+
+	for _, c := range cases {
+		decision := sprt.Decide(c.tally)
+		if decision != c.expected {
+			t.Errorf("Expected %s for %+v, instead got %s (ratio %f)", c.expected, c.tally, decision, sprt.LogLikelihoodRatio(c.tally))
+		}
+	}
+}
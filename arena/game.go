@@ -0,0 +1,115 @@
+package arena
+
+import (
+	"errors"
+	"fmt"
+	"reversi/core"
+	"reversi/engine"
+)
+
+var ErrIllegalMove = errors.New("illegal move")
+
+// Contestant makes a fresh player for every worker, players are not shared
+// between games running at the same time.
+type Contestant struct {
+	Name   string
+	New    func() (engine.Player, error)
+	Limits engine.Limits
+}
+
+type GameResult struct {
+	Black  string
+	White  string
+	Winner core.Player
+	Discs  map[core.Player]int
+	Moves  []core.Coordinate
+}
+
+type seat struct {
+	name   string
+	player engine.Player
+	limits engine.Limits
+}
+
+type gameFollower struct {
+	state core.GameState
+	moves []core.Coordinate
+}
+
+func (follower *gameFollower) SendEvent(event core.Event) {
+	if event.EventType == core.MOVED {
+		follower.moves = append(follower.moves, event.Data.(core.Coordinate))
+	}
+}
+
+func (follower *gameFollower) StateUpdated(gameState core.GameState) {
+	follower.state = gameState
+}
+
+type rejectRecorder struct {
+	rejected bool
+}
+
+func (recorder *rejectRecorder) InvalidCommand(command core.Command) {
+	recorder.rejected = true
+}
+
+// events hands each event to the follower and to the copy of the game it
+// uses to know whose turn it is.
+type events struct {
+	follower *gameFollower
+	mirror   core.StateUpdaterAndEventConsumer
+}
+
+func (events events) SendEvent(event core.Event) {
+	events.follower.SendEvent(event)
+	events.mirror.SendEvent(event)
+}
+
+// playGame plays the opening moves and then lets the players finish the game
+// through the same brain the server uses.
+func playGame(black seat, white seat, opening []core.Coordinate) (GameResult, error) {
+	follower := &gameFollower{}
+	mirror := core.NewGameEventAggregator()
+	mirror.Register(follower)
+
+	brain := core.NewQuietGameBrain(events{follower: follower, mirror: mirror})
+	rejects := &rejectRecorder{}
+	brain.Initialize(rejects)
+
+	for _, move := range opening {
+		brain.ExecuteCommand(core.NewMoveCommand(follower.state.PlayerTurn, move), rejects)
+		if rejects.rejected {
+			return GameResult{}, fmt.Errorf("%w in the opening: %s", ErrIllegalMove, move)
+		}
+	}
+
+	seats := map[core.Player]seat{core.BLACK: black, core.WHITE: white}
+	for !follower.state.IsOver() {
+		side := follower.state.PlayerTurn
+		current := seats[side]
+
+		result, err := current.player.BestMove(follower.state.Copy(), current.limits)
+		if err != nil {
+			return GameResult{}, fmt.Errorf("%s failed to pick a move: %w", current.name, err)
+		}
+
+		brain.ExecuteCommand(core.NewMoveCommand(side, result.Move), rejects)
+		if rejects.rejected {
+			return GameResult{}, fmt.Errorf("%w by %s: %s", ErrIllegalMove, current.name, result.Move)
+		}
+	}
+
+	winner, _ := follower.state.Winner()
+
+	return GameResult{
+		Black:  black.name,
+		White:  white.name,
+		Winner: winner,
+		Discs: map[core.Player]int{
+			core.BLACK: follower.state.DiscCount(core.BLACK),
+			core.WHITE: follower.state.DiscCount(core.WHITE),
+		},
+		Moves: follower.moves,
+	}, nil
+}
@@ -0,0 +1,47 @@
+package arena_test
+
+import (
+	"os"
+	"reversi/arena"
+	"reversi/core"
+	"reversi/engine"
+	"testing"
+)
+
+func Test_EveryLevel_beatsTheLevelBelowIt(t *testing.T) {
+	file, err := os.Open("testdata/openings4.txt")
+	if err != nil {
+		t.Fatalf("Failed to open the openings: %s", err.Error())
+	}
+	defer file.Close()
+
+	all, err := arena.ReadOpenings(file)
+	if err != nil {
+		t.Fatalf("Failed to read the openings: %s", err.Error())
+	}
+	openings := [][]core.Coordinate{}
+	for i := 0; i < len(all); i += 20 {
+		openings = append(openings, all[i])
+	}
+
+	for i := 1; i < len(engine.Levels); i++ {
+		weaker, stronger := engine.Levels[i-1], engine.Levels[i]
+
+		contestants := []arena.Contestant{}
+		for _, level := range []engine.Level{stronger, weaker} {
+			level := level
+			contestants = append(contestants, arena.Contestant{
+				Name: level.Name,
+				New:  func() (engine.Player, error) { return engine.NewLevelPlayer(level, 7), nil },
+			})
+		}
+
+		report, err := arena.Run(contestants, arena.Options{GamesPerPairing: 2 * len(openings), Openings: openings}, nil)
+		if err != nil {
+			t.Fatalf("Expected %s to play %s, instead got %s", stronger.Name, weaker.Name, err.Error())
+		}
+		if tally := report.Pairings[0].Tally; tally.Score() <= 0.5 {
+			t.Errorf("Expected %s to beat %s, instead scored +%d =%d -%d", stronger.Name, weaker.Name, tally.Wins, tally.Draws, tally.Losses)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package arena
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"reversi/core"
+	"strings"
+)
+
+var ErrBadOpening = errors.New("not an opening")
+
+// ParseOpening reads the moves in Othello notation, with or without spaces
+// between them, such as "c5 c4" or "c5c4".
+func ParseOpening(line string) ([]core.Coordinate, error) {
+	moves, err := core.ParseMoves(line)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrBadOpening, line)
+	}
+
+	return moves, nil
+}
+
+// ReadOpenings reads one opening a line, skipping blank lines and lines
+// starting with #.
+func ReadOpenings(reader io.Reader) ([][]core.Coordinate, error) {
+	openings := [][]core.Coordinate{}
+
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		opening, err := ParseOpening(line)
+		if err != nil {
+			return nil, err
+		}
+
+		openings = append(openings, opening)
+	}
+
+	return openings, scanner.Err()
+}
@@ -0,0 +1,248 @@
+package arena
+
+import (
+	"errors"
+	"reversi/core"
+	"reversi/engine"
+	"runtime"
+	"sync"
+)
+
+var ErrTooFewContestants = errors.New("a tournament needs at least two contestants")
+
+type Options struct {
+	// GamesPerPairing is rounded up to an even number, each opening is
+	// played twice with the colours swapped.
+	GamesPerPairing int
+	Openings        [][]core.Coordinate
+	// Workers is how many games are played at once, every core when 0.
+	Workers int
+	// SPRT stops a pairing once it is decided, when set.
+	SPRT *SPRT
+}
+
+// Pairing is the score of First against Second.
+type Pairing struct {
+	First    string
+	Second   string
+	Tally    Tally
+	Decision SPRTDecision
+}
+
+type Standing struct {
+	Name  string
+	Tally Tally
+}
+
+type Report struct {
+	Standings []Standing
+	Pairings  []Pairing
+}
+
+type job struct {
+	pairing int
+	game    int
+}
+
+type outcome struct {
+	job    job
+	result GameResult
+	err    error
+}
+
+type tournament struct {
+	contestants []Contestant
+	options     Options
+	pairs       [][2]int
+	lock        sync.Mutex
+	finished    []bool
+	failed      bool
+}
+
+func (tournament *tournament) skip(pairing int) bool {
+	tournament.lock.Lock()
+	defer tournament.lock.Unlock()
+
+	return tournament.failed || tournament.finished[pairing]
+}
+
+func (tournament *tournament) finish(pairing int) {
+	tournament.lock.Lock()
+	defer tournament.lock.Unlock()
+
+	tournament.finished[pairing] = true
+}
+
+func (tournament *tournament) fail() {
+	tournament.lock.Lock()
+	defer tournament.lock.Unlock()
+
+	tournament.failed = true
+}
+
+// schedule interleaves the pairings so they all progress together.
+func (tournament *tournament) schedule(jobs chan<- job) {
+	defer close(jobs)
+
+	for game := 0; game < tournament.options.GamesPerPairing; game++ {
+		for pairing := range tournament.pairs {
+			if !tournament.skip(pairing) {
+				jobs <- job{pairing: pairing, game: game}
+			}
+		}
+	}
+}
+
+// play runs games with players of its own, made the first time each
+// contestant is needed.
+func (tournament *tournament) play(jobs <-chan job, outcomes chan<- outcome) {
+	players := make(map[int]engine.Player)
+	playerFor := func(contestant int) (seat, error) {
+		player, made := players[contestant]
+		if !made {
+			var err error
+			player, err = tournament.contestants[contestant].New()
+			if err != nil {
+				return seat{}, err
+			}
+			players[contestant] = player
+		}
+
+		return seat{
+			name:   tournament.contestants[contestant].Name,
+			player: player,
+			limits: tournament.contestants[contestant].Limits,
+		}, nil
+	}
+
+	for job := range jobs {
+		if tournament.skip(job.pairing) {
+			continue
+		}
+
+		pair := tournament.pairs[job.pairing]
+		first, err := playerFor(pair[0])
+		if err != nil {
+			outcomes <- outcome{job: job, err: err}
+			continue
+		}
+		second, err := playerFor(pair[1])
+		if err != nil {
+			outcomes <- outcome{job: job, err: err}
+			continue
+		}
+
+		var opening []core.Coordinate
+		if len(tournament.options.Openings) > 0 {
+			opening = tournament.options.Openings[(job.game/2)%len(tournament.options.Openings)]
+		}
+
+		black, white := first, second
+		if firstSide(job.game) == core.WHITE {
+			black, white = second, first
+		}
+
+		result, err := playGame(black, white, opening)
+		outcomes <- outcome{job: job, result: result, err: err}
+	}
+}
+
+// firstSide is the colour the first contestant of a pairing plays, the
+// colours alternate from game to game.
+func firstSide(game int) core.Player {
+	if game%2 == 1 {
+		return core.WHITE
+	}
+
+	return core.BLACK
+}
+
+// record counts the game for the contestant that played side, by seat and not
+// by name so contestants sharing a name are kept apart.
+func record(tally *Tally, result GameResult, side core.Player) {
+	switch result.Winner {
+	case "":
+		tally.Draws++
+	case side:
+		tally.Wins++
+	default:
+		tally.Losses++
+	}
+}
+
+// Run plays every contestant against every other. progress, when given, is
+// told about each game as it finishes. The first game that fails stops the
+// tournament.
+func Run(contestants []Contestant, options Options, progress func(GameResult)) (Report, error) {
+	if len(contestants) < 2 {
+		return Report{}, ErrTooFewContestants
+	}
+
+	options.GamesPerPairing += options.GamesPerPairing % 2
+	if options.Workers <= 0 {
+		options.Workers = runtime.NumCPU()
+	}
+
+	tournament := &tournament{contestants: contestants, options: options}
+	report := Report{}
+	for i, contestant := range contestants {
+		report.Standings = append(report.Standings, Standing{Name: contestant.Name})
+		for j := i + 1; j < len(contestants); j++ {
+			tournament.pairs = append(tournament.pairs, [2]int{i, j})
+			report.Pairings = append(report.Pairings, Pairing{
+				First:    contestant.Name,
+				Second:   contestants[j].Name,
+				Decision: CONTINUE,
+			})
+		}
+	}
+	tournament.finished = make([]bool, len(tournament.pairs))
+
+	jobs := make(chan job)
+	outcomes := make(chan outcome)
+	go tournament.schedule(jobs)
+
+	var workers sync.WaitGroup
+	for i := 0; i < options.Workers; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			tournament.play(jobs, outcomes)
+		}()
+	}
+	go func() {
+		workers.Wait()
+		close(outcomes)
+	}()
+
+	var failure error
+	for outcome := range outcomes {
+		if outcome.err != nil {
+			if failure == nil {
+				failure = outcome.err
+				tournament.fail()
+			}
+			continue
+		}
+
+		pair := tournament.pairs[outcome.job.pairing]
+		pairing := &report.Pairings[outcome.job.pairing]
+		side := firstSide(outcome.job.game)
+		record(&pairing.Tally, outcome.result, side)
+		record(&report.Standings[pair[0]].Tally, outcome.result, side)
+		record(&report.Standings[pair[1]].Tally, outcome.result, side.Opposite())
+
+		if options.SPRT != nil && pairing.Decision == CONTINUE {
+			pairing.Decision = options.SPRT.Decide(pairing.Tally)
+			if pairing.Decision != CONTINUE {
+				tournament.finish(outcome.job.pairing)
+			}
+		}
+
+		if progress != nil {
+			progress(outcome.result)
+		}
+	}
+
+	return report, failure
+}
@@ -0,0 +1,143 @@
+package arena_test
+
+import (
+	"errors"
+	"reversi/arena"
+	"reversi/core"
+	"reversi/engine"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func contestant(name string, strategy engine.Strategy, limits engine.Limits) arena.Contestant {
+	return arena.Contestant{
+		Name:   name,
+		New:    func() (engine.Player, error) { return engine.NewPlayer(strategy, 7) },
+		Limits: limits,
+	}
+}
+
+func Test_Run_playsEveryPairingWithBothColours(t *testing.T) {
+	contestants := []arena.Contestant{
+		contestant("random", engine.RANDOM, engine.Limits{}),
+		contestant("greedy", engine.GREEDY, engine.Limits{}),
+		contestant("corners", engine.CORNERS, engine.Limits{}),
+	}
+
+	lock := sync.Mutex{}
+	blackGames := make(map[string]int)
+	report, err := arena.Run(contestants, arena.Options{GamesPerPairing: 5, Workers: 3}, func(result arena.GameResult) {
+		lock.Lock()
+		defer lock.Unlock()
+		blackGames[result.Black]++
+	})
+	if err != nil {
+		t.Fatalf("Expected the tournament to finish, instead got %s", err.Error())
+	}
+
+	if len(report.Pairings) != 3 {
+		t.Fatalf("Expected 3 pairings, instead got %d", len(report.Pairings))
+	}
+	for _, pairing := range report.Pairings {
+		if pairing.Tally.Games() != 6 {
+			t.Errorf("Expected 6 games between %s and %s, instead got %d", pairing.First, pairing.Second, pairing.Tally.Games())
+		}
+	}
+	for _, standing := range report.Standings {
+		if standing.Tally.Games() != 12 || blackGames[standing.Name] != 6 {
+			t.Errorf("Expected %s to play 12 games, half with black, instead got %d with %d as black", standing.Name, standing.Tally.Games(), blackGames[standing.Name])
+		}
+	}
+}
+
+func Test_Run_startsFromTheOpenings(t *testing.T) {
+	openings, err := arena.ReadOpenings(strings.NewReader("# two balanced lines\nc5 c4\n\nc5c6\n"))
+	if err != nil || len(openings) != 2 {
+		t.Fatalf("Expected two openings, instead got %v and %v", openings, err)
+	}
+
+	lock := sync.Mutex{}
+	seen := make(map[core.Coordinate]int)
+	_, err = arena.Run([]arena.Contestant{
+		contestant("random", engine.RANDOM, engine.Limits{}),
+		contestant("greedy", engine.GREEDY, engine.Limits{}),
+	}, arena.Options{GamesPerPairing: 4, Openings: openings}, func(result arena.GameResult) {
+		lock.Lock()
+		defer lock.Unlock()
+		seen[result.Moves[1]]++
+	})
+	if err != nil {
+		t.Fatalf("Expected the tournament to finish, instead got %s", err.Error())
+	}
+
+	if seen[core.Coordinate{X: 2, Y: 3}] != 2 || seen[core.Coordinate{X: 2, Y: 5}] != 2 {
+		t.Errorf("Expected each opening to be played twice, instead got %v", seen)
+	}
+}
+
+func Test_Run_withAnIllegalOpening_fails(t *testing.T) {
+	opening, _ := arena.ParseOpening("a1")
+
+	_, err := arena.Run([]arena.Contestant{
+		contestant("random", engine.RANDOM, engine.Limits{}),
+		contestant("greedy", engine.GREEDY, engine.Limits{}),
+	}, arena.Options{GamesPerPairing: 2, Openings: [][]core.Coordinate{opening}}, nil)
+
+	if !errors.Is(err, arena.ErrIllegalMove) {
+		t.Errorf("Expected an illegal move error, instead got %v", err)
+	}
+}
+
+func Test_Run_stopsAPairingTheSPRTHasDecided(t *testing.T) {
+	report, err := arena.Run([]arena.Contestant{
+		contestant("search", engine.SEARCH, engine.Limits{Depth: 3}),
+		contestant("random", engine.RANDOM, engine.Limits{}),
+	}, arena.Options{
+		GamesPerPairing: 400,
+		Workers:         2,
+		SPRT:            &arena.SPRT{Elo0: 0, Elo1: 200, Alpha: 0.05, Beta: 0.05},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Expected the tournament to finish, instead got %s", err.Error())
+	}
+
+	pairing := report.Pairings[0]
+	if pairing.Decision != arena.ACCEPT_H1 || pairing.Tally.Games() >= 400 {
+		t.Errorf("Expected search to be shown stronger well before 400 games, instead got %s after %d", pairing.Decision, pairing.Tally.Games())
+	}
+}
+
+func Test_ParseOpening_rejectsWhatIsNotAMove(t *testing.T) {
+	for _, line := range []string{"c5 c", "i1", "c9", "55"} {
+		_, err := arena.ParseOpening(line)
+		if !errors.Is(err, arena.ErrBadOpening) {
+			t.Errorf("Expected %q to be rejected, instead got %v", line, err)
+		}
+	}
+}
+
+func Test_Run_keepsContestantsWithTheSameNameApart(t *testing.T) {
+	named := func(first string, second string) arena.Report {
+		contestants := []arena.Contestant{
+			contestant(first, engine.GREEDY, engine.Limits{}),
+			contestant(second, engine.RANDOM, engine.Limits{}),
+		}
+
+		report, err := arena.Run(contestants, arena.Options{GamesPerPairing: 10, Workers: 1}, nil)
+		if err != nil {
+			t.Fatalf("Expected the tournament to finish, instead got %s", err.Error())
+		}
+		return report
+	}
+
+	apart, same := named("greedy", "random"), named("search", "search")
+	if same.Pairings[0].Tally != apart.Pairings[0].Tally {
+		t.Errorf("Expected %+v whatever the names, instead got %+v", apart.Pairings[0].Tally, same.Pairings[0].Tally)
+	}
+	for i := range same.Standings {
+		if same.Standings[i].Tally != apart.Standings[i].Tally {
+			t.Errorf("Expected contestant %d to score %+v, instead got %+v", i+1, apart.Standings[i].Tally, same.Standings[i].Tally)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package book
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reversi/core"
+	"reversi/records"
+	"sort"
+)
+
+const DefaultPlies = 20
+
+var ErrIllegalMove = core.ErrIllegalMove
+
+// MoveStats counts the results of the games where a move was played, from
+// the point of view of the side that played it.
+type MoveStats struct {
+	Move   core.Coordinate
+	Games  int
+	Wins   int
+	Draws  int
+	Losses int
+}
+
+// Score is the share of the points the move earned, a draw is half a point.
+func (stats MoveStats) Score() float64 {
+	if stats.Games == 0 {
+		return 0
+	}
+
+	return (float64(stats.Wins) + float64(stats.Draws)/2) / float64(stats.Games)
+}
+
+// Book remembers the moves played in positions seen before. Positions are
+// stored once for all eight turns and mirrors of the board.
+type Book struct {
+	Positions map[string][]MoveStats
+}
+
+func (book *Book) record(gameState core.GameState, move core.Coordinate, winner core.Player) {
+	orientation := canonical(gameState)
+	move = orientation.toCanonical(move)
+
+	moves := book.Positions[orientation.key]
+	index := -1
+	for i, stats := range moves {
+		if stats.Move == move {
+			index = i
+		}
+	}
+	if index < 0 {
+		moves = append(moves, MoveStats{Move: move})
+		index = len(moves) - 1
+	}
+
+	stats := &moves[index]
+	stats.Games++
+	switch winner {
+	case "":
+		stats.Draws++
+	case gameState.PlayerTurn:
+		stats.Wins++
+	default:
+		stats.Losses++
+	}
+
+	book.Positions[orientation.key] = moves
+}
+
+// Add counts the first plies moves of a game won by winner, an empty winner
+// is a draw.
+func (book *Book) Add(moves []core.Coordinate, winner core.Player, plies int) error {
+	if len(moves) > plies {
+		moves = moves[:plies]
+	}
+
+	states, err := core.Replay(core.StartingPosition(), moves)
+	if err != nil {
+		return err
+	}
+
+	for i, move := range moves {
+		book.record(states[i], move, winner)
+	}
+
+	return nil
+}
+
+// Lookup lists the moves the book knows in the position, turned back to the
+// way the board is, the best scoring first.
+func (book *Book) Lookup(gameState core.GameState) []MoveStats {
+	orientation := canonical(gameState)
+
+	result := []MoveStats{}
+	for _, stats := range book.Positions[orientation.key] {
+		stats.Move = orientation.fromCanonical(stats.Move)
+		result = append(result, stats)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Score() != result[j].Score() {
+			return result[i].Score() > result[j].Score()
+		}
+		return result[i].Games > result[j].Games
+	})
+
+	return result
+}
+
+func (book *Book) Size() int {
+	return len(book.Positions)
+}
+
+// Save writes the book to a temporary file first so a failed write leaves
+// the old book in place.
+func (book *Book) Save(path string) error {
+	data, err := json.Marshal(book)
+	if err != nil {
+		return err
+	}
+
+	temporary := path + ".tmp"
+	err = ioutil.WriteFile(temporary, data, 0644)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(temporary, path)
+}
+
+func New() *Book {
+	return &Book{Positions: make(map[string][]MoveStats)}
+}
+
+func Load(path string) (*Book, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	book := New()
+	err = json.Unmarshal(data, book)
+	if err != nil {
+		return nil, err
+	}
+	if book.Positions == nil {
+		book.Positions = make(map[string][]MoveStats)
+	}
+
+	return book, nil
+}
+
+// Build makes a book out of recorded games. Forfeited games are left out,
+// their result says nothing about the opening.
+func Build(games []records.GameRecord, plies int) (*Book, error) {
+	if plies <= 0 {
+		plies = DefaultPlies
+	}
+
+	book := New()
+	for _, game := range games {
+		if game.Forfeit != "" {
+			continue
+		}
+
+		err := book.Add(game.Moves, game.Winner, plies)
+		if err != nil {
+			return nil, fmt.Errorf("game %s: %w", game.GameId, err)
+		}
+	}
+
+	return book, nil
+}
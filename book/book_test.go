@@ -0,0 +1,135 @@
+package book_test
+
+import (
+	"errors"
+	"path/filepath"
+	"reversi/book"
+	"reversi/core"
+	"reversi/engine"
+	"reversi/records"
+	"testing"
+)
+
+var opening = []core.Coordinate{
+	{X: 2, Y: 4}, {X: 2, Y: 5}, {X: 2, Y: 6}, {X: 1, Y: 4}, {X: 0, Y: 4}, {X: 4, Y: 5},
+	{X: 5, Y: 2}, {X: 4, Y: 2}, {X: 3, Y: 2}, {X: 2, Y: 1},
+}
+
+func stateAfter(moves []core.Coordinate) core.GameState {
+	states, err := core.Replay(core.StartingPosition(), moves)
+	if err != nil {
+		panic(err)
+	}
+
+	return states[len(states)-1]
+}
+
+func transpose(moves []core.Coordinate) []core.Coordinate {
+	result := []core.Coordinate{}
+	for _, move := range moves {
+		result = append(result, core.Coordinate{X: move.Y, Y: move.X})
+	}
+
+	return result
+}
+
+func Test_Lookup_inATurnedPosition_answersForTheBoardAsItIs(t *testing.T) {
+	openingBook := book.New()
+	openingBook.Add(opening, core.BLACK, book.DefaultPlies)
+
+	// The starting position is the same after a transpose, so the whole
+	// opening can be played that way. In the starting position itself every
+	// first move is as good as the other, the next test covers it.
+	turned := transpose(opening)
+	for played := 1; played < len(opening); played++ {
+		moves := openingBook.Lookup(stateAfter(turned[:played]))
+		if len(moves) != 1 || moves[0].Move != turned[played] {
+			t.Fatalf("Expected (%d, %d) after %d moves, instead got %+v", turned[played].X, turned[played].Y, played, moves)
+		}
+	}
+}
+
+func Test_EquivalentOpeningMoves_areCountedTogether(t *testing.T) {
+	openingBook := book.New()
+	start := stateAfter(nil)
+	for move := range start.MoveOptions() {
+		openingBook.Add([]core.Coordinate{move}, core.WHITE, book.DefaultPlies)
+	}
+
+	moves := openingBook.Lookup(start)
+	if len(moves) != 1 || moves[0].Games != len(start.MoveOptions()) {
+		t.Fatalf("Every first move is the same up to symmetry, instead got %+v", moves)
+	}
+	if !start.MoveOptions()[moves[0].Move] {
+		t.Errorf("(%d, %d) is not a legal first move", moves[0].Move.X, moves[0].Move.Y)
+	}
+	if moves[0].Losses != moves[0].Games || moves[0].Score() != 0 {
+		t.Errorf("Black lost every game, instead got %+v", moves[0])
+	}
+}
+
+func Test_Build_countsResultsForTheSideThatMoved(t *testing.T) {
+	games := []records.GameRecord{
+		{GameId: "1", Moves: opening, Winner: core.WHITE},
+		{GameId: "2", Moves: opening},
+		{GameId: "3", Moves: opening, Winner: core.BLACK, Forfeit: core.WHITE},
+	}
+
+	openingBook, err := book.Build(games, 4)
+	if err != nil {
+		t.Fatalf("Expected a book, instead got %s", err.Error())
+	}
+	if openingBook.Size() != 4 {
+		t.Errorf("Expected the 4 positions before the first 4 moves, instead got %d", openingBook.Size())
+	}
+
+	stats := openingBook.Lookup(stateAfter(opening[:1]))[0]
+	if stats.Games != 2 || stats.Wins != 1 || stats.Draws != 1 || stats.Score() != 0.75 {
+		t.Errorf("White won one game and drew one, instead got %+v", stats)
+	}
+
+	_, err = book.Build([]records.GameRecord{{GameId: "bad", Moves: []core.Coordinate{{X: 0, Y: 0}}}}, 4)
+	if !errors.Is(err, book.ErrIllegalMove) {
+		t.Errorf("Expected an illegal move error, instead got %v", err)
+	}
+}
+
+func Test_SavedBook_canBeLoaded(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "book.json")
+
+	openingBook := book.New()
+	openingBook.Add(opening, core.BLACK, book.DefaultPlies)
+	err := openingBook.Save(path)
+	if err != nil {
+		t.Fatalf("Expected the book to be saved, instead got %s", err.Error())
+	}
+
+	loaded, err := book.Load(path)
+	if err != nil {
+		t.Fatalf("Expected the book to load, instead got %s", err.Error())
+	}
+
+	state := stateAfter(opening[:5])
+	if loaded.Size() != openingBook.Size() || loaded.Lookup(state)[0] != openingBook.Lookup(state)[0] {
+		t.Errorf("Expected the loaded book to match the saved one")
+	}
+}
+
+func Test_BookPlayer_leavesTheBookForTheFallback(t *testing.T) {
+	openingBook := book.New()
+	openingBook.Add(opening[:2], core.BLACK, book.DefaultPlies)
+
+	fallback, _ := engine.NewPlayer(engine.GREEDY, 1)
+	player := book.NewPlayer(openingBook, fallback, 1)
+
+	result, err := player.BestMove(stateAfter(opening[:1]), engine.Limits{})
+	if err != nil || result.Move != opening[1] {
+		t.Errorf("Expected the book move (%d, %d), instead got %+v (%v)", opening[1].X, opening[1].Y, result, err)
+	}
+
+	state := stateAfter(opening[:2])
+	result, err = player.BestMove(state, engine.Limits{})
+	if err != nil || !state.MoveOptions()[result.Move] {
+		t.Errorf("Expected the fallback to play a legal move, instead got %+v (%v)", result, err)
+	}
+}
@@ -0,0 +1,43 @@
+package book
+
+import (
+	"io"
+	"reversi/core"
+	"reversi/engine"
+)
+
+// Player plays the best book move that has been played often enough, and
+// asks the fallback once the game leaves the book.
+type Player struct {
+	book         *Book
+	fallback     engine.Player
+	minimumGames int
+}
+
+func (player *Player) BestMove(gameState core.GameState, limits engine.Limits) (engine.Result, error) {
+	for _, stats := range player.book.Lookup(gameState) {
+		if stats.Games >= player.minimumGames && gameState.MoveOptions()[stats.Move] {
+			return engine.Result{Move: stats.Move, PrincipalVariation: []core.Coordinate{stats.Move}}, nil
+		}
+	}
+
+	return player.fallback.BestMove(gameState, limits)
+}
+
+// Close closes the fallback when it holds on to something, such as an
+// external engine.
+func (player *Player) Close() error {
+	if closer, ok := player.fallback.(io.Closer); ok {
+		return closer.Close()
+	}
+
+	return nil
+}
+
+func NewPlayer(book *Book, fallback engine.Player, minimumGames int) *Player {
+	if minimumGames < 1 {
+		minimumGames = 1
+	}
+
+	return &Player{book: book, fallback: fallback, minimumGames: minimumGames}
+}
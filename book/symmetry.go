@@ -0,0 +1,92 @@
+package book
+
+import (
+	"reversi/core"
+)
+
+// symmetry is one of the eight ways to turn or mirror the board that leave
+// the rules of the game unchanged.
+type symmetry func(core.Coordinate) core.Coordinate
+
+var symmetries = []symmetry{
+	func(c core.Coordinate) core.Coordinate { return c },
+	func(c core.Coordinate) core.Coordinate { return core.Coordinate{X: 7 - c.Y, Y: c.X} },
+	func(c core.Coordinate) core.Coordinate { return core.Coordinate{X: 7 - c.X, Y: 7 - c.Y} },
+	func(c core.Coordinate) core.Coordinate { return core.Coordinate{X: c.Y, Y: 7 - c.X} },
+	func(c core.Coordinate) core.Coordinate { return core.Coordinate{X: 7 - c.X, Y: c.Y} },
+	func(c core.Coordinate) core.Coordinate { return core.Coordinate{X: c.X, Y: 7 - c.Y} },
+	func(c core.Coordinate) core.Coordinate { return core.Coordinate{X: c.Y, Y: c.X} },
+	func(c core.Coordinate) core.Coordinate { return core.Coordinate{X: 7 - c.Y, Y: 7 - c.X} },
+}
+
+// inverses undo the symmetry at the same index, the quarter turns undo each
+// other and the rest undo themselves.
+var inverses = []int{0, 3, 2, 1, 4, 5, 6, 7}
+
+func squareOf(coordinate core.Coordinate) byte {
+	return byte(coordinate.Y*8 + coordinate.X)
+}
+
+func transformedKey(gameState core.GameState, transform symmetry) string {
+	cells := make([]byte, 65)
+	for x := 0; x < 8; x++ {
+		for y := 0; y < 8; y++ {
+			coordinate := core.Coordinate{X: x, Y: y}
+			cell := byte('-')
+			if claim, found := gameState.Board[coordinate]; found && claim.OwnedBy(core.BLACK) {
+				cell = 'X'
+			} else if found && claim.OwnedBy(core.WHITE) {
+				cell = 'O'
+			}
+			cells[squareOf(transform(coordinate))] = cell
+		}
+	}
+
+	cells[64] = 'X'
+	if gameState.PlayerTurn == core.WHITE {
+		cells[64] = 'O'
+	}
+
+	return string(cells)
+}
+
+// orientation is how a position was turned to find its key. A position
+// that looks the same under several symmetries has them all in matching.
+type orientation struct {
+	key      string
+	matching []int
+}
+
+// canonical picks the smallest key of the eight orientations, so the same
+// position however it is turned is stored once.
+func canonical(gameState core.GameState) orientation {
+	result := orientation{}
+	for index, transform := range symmetries {
+		key := transformedKey(gameState, transform)
+		if result.matching == nil || key < result.key {
+			result = orientation{key: key, matching: []int{index}}
+		} else if key == result.key {
+			result.matching = append(result.matching, index)
+		}
+	}
+
+	return result
+}
+
+// toCanonical turns a move into the book's orientation. Moves that are the
+// same because the position is symmetric all turn into the same square.
+func (orientation orientation) toCanonical(move core.Coordinate) core.Coordinate {
+	best := symmetries[orientation.matching[0]](move)
+	for _, index := range orientation.matching[1:] {
+		candidate := symmetries[index](move)
+		if squareOf(candidate) < squareOf(best) {
+			best = candidate
+		}
+	}
+
+	return best
+}
+
+func (orientation orientation) fromCanonical(move core.Coordinate) core.Coordinate {
+	return symmetries[inverses[orientation.matching[0]]](move)
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"reversi/analysis"
+	"reversi/core"
+	"reversi/records"
+	"strings"
+	"text/tabwriter"
+)
+
+func findGame(store *records.Store, gameId string) (records.GameRecord, error) {
+	games, err := store.ReadAll()
+	if err != nil {
+		return records.GameRecord{}, err
+	}
+	if len(games) == 0 {
+		return records.GameRecord{}, fmt.Errorf("no games have been recorded")
+	}
+
+	if gameId == "" {
+		return games[len(games)-1], nil
+	}
+
+	for _, game := range games {
+		if game.GameId == gameId {
+			return game, nil
+		}
+	}
+
+	return records.GameRecord{}, fmt.Errorf("no game found with id %s", gameId)
+}
+
+func formatScore(score float64, exact bool) string {
+	if exact {
+		return fmt.Sprintf("%+.0f", score)
+	}
+
+	return fmt.Sprintf("%+.1f", score)
+}
+
+// printAlternatives compares the moves that lost something with the best
+// moves of their position.
+func printAlternatives(game records.GameRecord, report analysis.Report) {
+	for _, move := range report.Moves {
+		if len(move.Alternatives) == 0 || move.Classification == analysis.BEST || move.Classification == analysis.GOOD {
+			continue
+		}
+
+		fmt.Printf("\nMove %d, %s played %s for %+.1f, instead:\n", move.Number, game.NameOf(move.Side), move.Played, move.PlayedScore)
+		for _, alternative := range move.Alternatives {
+			fmt.Printf("  %+.1f  %s\n", alternative.Score, core.FormatMoves(alternative.Line))
+		}
+	}
+}
+
+func printReport(game records.GameRecord, report analysis.Report) {
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(table, "Move\tPlayer\tPlayed\tScore\tBest\tScore\tLoss\t")
+	for _, move := range report.Moves {
+		classification := ""
+		if move.Classification != analysis.BEST && move.Classification != analysis.GOOD {
+			classification = strings.ToLower(string(move.Classification))
+		}
+
+		fmt.Fprintf(table, "%d\t%s\t%s\t%s\t%s\t%s\t%.1f\t%s\n",
+			move.Number, game.NameOf(move.Side),
+			move.Played, formatScore(move.PlayedScore, move.Exact),
+			move.Best, formatScore(move.BestScore, move.Exact),
+			move.Loss, classification)
+	}
+	table.Flush()
+
+	fmt.Println()
+	for _, side := range []core.Player{core.BLACK, core.WHITE} {
+		summary := report.Players[side]
+		fmt.Printf("%s (%s): accuracy %.1f%%, %.2f discs lost a move, %d inaccuracies, %d mistakes, %d blunders\n",
+			game.NameOf(side), side, summary.Accuracy, summary.AverageLoss, summary.Inaccuracies, summary.Mistakes, summary.Blunders)
+	}
+
+	printAlternatives(game, report)
+}
+
+func main() {
+	gamesFile := flag.String("games", "games.jsonl", "file where the server recorded the games")
+	gameId := flag.String("game", "", "id of the game to analyse, the last one recorded when not given")
+	depth := flag.Int("depth", analysis.DefaultDepth, "how far every position is searched")
+	exact := flag.Int("exact", analysis.DefaultExactEmpties, "positions with this many empty squares or fewer are solved exactly")
+	workers := flag.Int("workers", 0, "threads each search uses, 0 uses every core")
+	alternatives := flag.Int("alternatives", 0, "best moves to compare with each inaccuracy, mistake and blunder, 0 for none")
+	flag.Parse()
+
+	game, err := findGame(records.NewStore(*gamesFile), strings.TrimSpace(*gameId))
+	if err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+
+	fmt.Printf("Analysing game %s, %s against %s\n", game.GameId, game.Black, game.White)
+	fmt.Println(core.FormatMoves(game.Moves))
+
+	report, err := analysis.AnalyseGame(game.Moves, analysis.Options{Depth: *depth, ExactEmpties: *exact, Workers: *workers, Alternatives: *alternatives}, func(move analysis.MoveAnalysis) {
+		fmt.Printf("\rmove %d of %d", move.Number, len(game.Moves))
+	})
+	fmt.Print("\r")
+	if err != nil {
+		fmt.Printf("failed to analyse the game: %s\n", err.Error())
+		return
+	}
+
+	printReport(game, report)
+}
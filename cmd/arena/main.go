@@ -0,0 +1,189 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"reversi/arena"
+	"reversi/core"
+	"reversi/engine"
+	"strings"
+	"sync/atomic"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	progressInterval = 10
+	// patternPlayer searches with the -weights instead of the square weights.
+	patternPlayer = "pattern"
+)
+
+// parseContestant reads "strategy" or "strategy:think", the think time
+// overriding the default for that player. A level plays at that difficulty
+// and a pattern player is a search with the trained weights.
+func parseContestant(spec string, thinkTime time.Duration, depth int, seed int64, evaluator engine.Evaluator) (arena.Contestant, error) {
+	parts := strings.SplitN(strings.TrimSpace(spec), ":", 2)
+
+	newPlayer := func() (engine.Player, error) {
+		return engine.NewSearcher(evaluator), nil
+	}
+	if level, err := engine.ParseLevel(parts[0]); err == nil {
+		newPlayer = func() (engine.Player, error) {
+			return engine.NewLevelPlayer(level, seed), nil
+		}
+	} else if strings.ToLower(parts[0]) != patternPlayer {
+		strategy, err := engine.ParseStrategy(parts[0])
+		if err != nil {
+			return arena.Contestant{}, fmt.Errorf("%s: %s", err.Error(), parts[0])
+		}
+		newPlayer = func() (engine.Player, error) {
+			return engine.NewPlayer(strategy, seed)
+		}
+	} else if evaluator == nil {
+		return arena.Contestant{}, fmt.Errorf("%s players need -weights", patternPlayer)
+	}
+
+	if len(parts) == 2 {
+		parsed, err := time.ParseDuration(parts[1])
+		if err != nil {
+			return arena.Contestant{}, err
+		}
+		thinkTime = parsed
+	}
+
+	return arena.Contestant{
+		Name:   strings.TrimSpace(spec),
+		New:    newPlayer,
+		Limits: engine.Limits{Time: thinkTime, Depth: depth},
+	}, nil
+}
+
+func readOpenings(path string) ([][]core.Coordinate, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return arena.ReadOpenings(file)
+}
+
+func formatElo(value float64) string {
+	if math.IsInf(value, 0) {
+		if value > 0 {
+			return "+inf"
+		}
+		return "-inf"
+	}
+
+	return fmt.Sprintf("%+.1f", value)
+}
+
+func printReport(report arena.Report, sprt *arena.SPRT) {
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(table, "Player\tGames\tWins\tDraws\tLosses\tScore")
+	for _, standing := range report.Standings {
+		tally := standing.Tally
+		fmt.Fprintf(table, "%s\t%d\t%d\t%d\t%d\t%.1f%%\n", standing.Name, tally.Games(), tally.Wins, tally.Draws, tally.Losses, 100*tally.Score())
+	}
+	fmt.Fprintln(table)
+
+	fmt.Fprintln(table, "Pairing\tResult\tElo\tSPRT")
+	for _, pairing := range report.Pairings {
+		tally := pairing.Tally
+		elo, margin := tally.Elo()
+
+		decision := "-"
+		if sprt != nil {
+			decision = fmt.Sprintf("%s (LLR %.2f)", pairing.Decision, sprt.LogLikelihoodRatio(tally))
+		}
+
+		fmt.Fprintf(table, "%s vs %s\t+%d =%d -%d\t%s ± %s\t%s\n", pairing.First, pairing.Second, tally.Wins, tally.Draws, tally.Losses, formatElo(elo), strings.TrimPrefix(formatElo(margin), "+"), decision)
+	}
+
+	table.Flush()
+}
+
+func main() {
+	players := flag.String("players", "search,greedy", "comma separated players, each a strategy, level or pattern with an optional think time such as search:200ms")
+	thinkTime := flag.Duration("think", 100*time.Millisecond, "time each player may think about a move")
+	depth := flag.Int("depth", 0, "search depth limit for the search player, 0 for none")
+	games := flag.Int("games", 100, "games between each pair of players, rounded up to an even number")
+	openingsFile := flag.String("openings", "", "file with one opening a line, such as c5 c4, played once with each colour")
+	workers := flag.Int("workers", 0, "games played at once, 0 uses every core")
+	useSPRT := flag.Bool("sprt", false, "stop a pairing once the SPRT decides between -elo0 and -elo1")
+	elo0 := flag.Float64("elo0", 0, "Elo difference of the SPRT null hypothesis")
+	elo1 := flag.Float64("elo1", 20, "Elo difference of the SPRT alternative hypothesis")
+	alpha := flag.Float64("alpha", 0.05, "chance the SPRT wrongly accepts -elo1")
+	beta := flag.Float64("beta", 0.05, "chance the SPRT wrongly accepts -elo0")
+	seed := flag.Int64("seed", 0, "seed for the random choices, 0 picks one from the clock")
+	weightsFile := flag.String("weights", "", "trained pattern weights, lets pattern players search with them")
+	flag.Parse()
+
+	var evaluator engine.Evaluator
+	if *weightsFile != "" {
+		var err error
+		evaluator, err = engine.LoadPatternEvaluator(*weightsFile)
+		if err != nil {
+			fmt.Printf("failed to load the pattern weights: %s\n", err.Error())
+			return
+		}
+	}
+
+	contestants := []arena.Contestant{}
+	names := make(map[string]bool)
+	for _, spec := range strings.Split(*players, ",") {
+		contestant, err := parseContestant(spec, *thinkTime, *depth, *seed, evaluator)
+		if err != nil {
+			fmt.Println(err.Error())
+			return
+		}
+		if names[contestant.Name] {
+			fmt.Printf("%s is listed twice\n", contestant.Name)
+			return
+		}
+
+		names[contestant.Name] = true
+		contestants = append(contestants, contestant)
+	}
+
+	openings, err := readOpenings(*openingsFile)
+	if err != nil {
+		fmt.Printf("failed to read the openings: %s\n", err.Error())
+		return
+	}
+
+	options := arena.Options{
+		GamesPerPairing: *games,
+		Openings:        openings,
+		Workers:         *workers,
+	}
+	if *useSPRT {
+		options.SPRT = &arena.SPRT{Elo0: *elo0, Elo1: *elo1, Alpha: *alpha, Beta: *beta}
+	}
+
+	var played int64
+	report, err := arena.Run(contestants, options, func(result arena.GameResult) {
+		if atomic.AddInt64(&played, 1)%progressInterval == 0 {
+			fmt.Printf("%d games played\n", atomic.LoadInt64(&played))
+		}
+	})
+	if errors.Is(err, arena.ErrTooFewContestants) {
+		fmt.Println(err.Error())
+		return
+	}
+	if err != nil {
+		fmt.Printf("the tournament stopped: %s\n", err.Error())
+	}
+
+	fmt.Println()
+	printReport(report, options.SPRT)
+}
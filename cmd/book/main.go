@@ -0,0 +1,35 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"reversi/book"
+	"reversi/records"
+)
+
+func main() {
+	gamesFile := flag.String("games", "games.jsonl", "file where the server recorded the games")
+	out := flag.String("out", "book.json", "file the opening book is written to")
+	plies := flag.Int("plies", book.DefaultPlies, "how many moves of each game go into the book")
+	flag.Parse()
+
+	games, err := records.NewStore(*gamesFile).ReadAll()
+	if err != nil {
+		fmt.Printf("failed to read the games: %s\n", err.Error())
+		return
+	}
+
+	openingBook, err := book.Build(games, *plies)
+	if err != nil {
+		fmt.Printf("failed to build the book: %s\n", err.Error())
+		return
+	}
+
+	err = openingBook.Save(*out)
+	if err != nil {
+		fmt.Printf("failed to save the book: %s\n", err.Error())
+		return
+	}
+
+	fmt.Printf("Wrote %d positions from %d games to %s\n", openingBook.Size(), len(games), *out)
+}
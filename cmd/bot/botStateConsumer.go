@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	reversi_core "reversi/core"
+	"reversi/engine"
+	"time"
+)
+
+// botStateConsumer answers every state where it is the bot's turn with the
+// move its player picks.
+type botStateConsumer struct {
+	side        reversi_core.Player
+	player      engine.Player
+	thinkTime   time.Duration
+	moveChannel chan<- reversi_core.Coordinate
+}
+
+func (consumer *botStateConsumer) StateUpdated(gameState reversi_core.GameState) {
+	if gameState.IsOver() || gameState.PlayerTurn != consumer.side {
+		return
+	}
+
+	result, err := consumer.player.BestMove(gameState.Copy(), engine.Limits{Time: consumer.thinkTime})
+	if err != nil {
+		fmt.Printf("Failed to pick a move: %s\n", err.Error())
+		return
+	}
+
+	if result.Nodes > 0 {
+		fmt.Printf("Playing %s after %s, depth %d, %.0f nodes a second\n", result.Move, result.Elapsed.Round(time.Millisecond), result.Depth, result.NodesPerSecond())
+	} else {
+		fmt.Printf("Playing %s after %s\n", result.Move, result.Elapsed.Round(time.Millisecond))
+	}
+	consumer.moveChannel <- result.Move
+}
+
+func newBotStateConsumer(side reversi_core.Player, player engine.Player, thinkTime time.Duration, moveChannel chan<- reversi_core.Coordinate) reversi_core.StateUpdateConsumer {
+	return &botStateConsumer{
+		side:        side,
+		player:      player,
+		thinkTime:   thinkTime,
+		moveChannel: moveChannel,
+	}
+}
@@ -0,0 +1,256 @@
+package main
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"reversi/book"
+	reversi_core "reversi/core"
+	"reversi/engine"
+	"reversi/tcpimpl"
+	"strings"
+	"time"
+)
+
+const retryDelay = 5 * time.Second
+
+type incomingMessage struct {
+	EventType        reversi_core.EventType
+	NotificationType tcpimpl.NotificationType
+	Side             reversi_core.Player
+	GameId           string
+	Opponent         string
+	Data             json.RawMessage
+}
+
+func convertToEvent(message incomingMessage) (reversi_core.Event, error) {
+	if message.EventType == reversi_core.MOVED {
+		coordinate := reversi_core.Coordinate{}
+		err := json.Unmarshal(message.Data, &coordinate)
+		if err != nil {
+			return reversi_core.Event{}, err
+		}
+
+		return reversi_core.NewMoveEvent(coordinate), nil
+	}
+
+	return reversi_core.Event{EventType: message.EventType}, nil
+}
+
+func dial(server string, options tcpimpl.ClientTLSOptions) (net.Conn, error) {
+	if !options.Enabled() {
+		return net.Dial("tcp", server)
+	}
+
+	config, err := tcpimpl.NewClientTLSConfig(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return tls.Dial("tcp", server, config)
+}
+
+func send(connection net.Conn, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	_, err = connection.Write(append(data, '\n'))
+	return err
+}
+
+func reply(connection net.Conn, moveChannel <-chan reversi_core.Coordinate) {
+	for move := range moveChannel {
+		err := send(connection, move)
+		if err != nil {
+			fmt.Printf("Error writing the move %s\n", err.Error())
+		}
+	}
+}
+
+func printGameOver(data json.RawMessage) {
+	gameOver := tcpimpl.GameOver{}
+	err := json.Unmarshal(data, &gameOver)
+	if err != nil {
+		fmt.Printf("Failed to read the result %s\n", err.Error())
+		return
+	}
+
+	winner := "nobody, it is a draw"
+	if gameOver.Winner != "" {
+		winner = gameOver.Players[gameOver.Winner]
+	}
+	fmt.Printf("Game %s over %d - %d, winner %s\n", gameOver.GameId, gameOver.Discs[reversi_core.BLACK], gameOver.Discs[reversi_core.WHITE], winner)
+}
+
+type bot struct {
+	server     string
+	tlsOptions tcpimpl.ClientTLSOptions
+	handshake  tcpimpl.Handshake
+	strategy   engine.Strategy
+	thinkTime  time.Duration
+	seed       int64
+	book       *book.Book
+	bookGames  int
+	evaluator  engine.Evaluator
+	workers    int
+}
+
+// play connects, waits for an opponent and plays one game.
+func (bot *bot) play() error {
+	connection, err := dial(bot.server, bot.tlsOptions)
+	if err != nil {
+		return err
+	}
+	defer connection.Close()
+
+	err = send(connection, bot.handshake)
+	if err != nil {
+		return err
+	}
+
+	player, err := engine.NewPlayer(bot.strategy, bot.seed)
+	if err != nil {
+		return err
+	}
+	if bot.strategy == engine.SEARCH {
+		evaluator := bot.evaluator
+		if evaluator == nil {
+			evaluator = engine.NewWeightedSquareEvaluator()
+		}
+		player = engine.NewParallelSearcher(evaluator, bot.workers)
+	}
+	if bot.book != nil {
+		player = book.NewPlayer(bot.book, player, bot.bookGames)
+	}
+
+	moveChannel := make(chan reversi_core.Coordinate)
+	defer close(moveChannel)
+	go reply(connection, moveChannel)
+
+	gameState := reversi_core.NewGameEventAggregator()
+	gameStarted := false
+
+	scanner := bufio.NewScanner(connection)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		message := incomingMessage{}
+		err := json.Unmarshal([]byte(line), &message)
+		if err != nil {
+			fmt.Println(line)
+			if !gameStarted {
+				return errors.New(line)
+			}
+			// The server rejected the move, pick again.
+			gameState.SendEvent(reversi_core.Event{})
+			continue
+		}
+
+		switch {
+		case message.NotificationType == tcpimpl.GAME_OVER:
+			printGameOver(message.Data)
+			return nil
+		case message.NotificationType != "":
+			continue
+		case !gameStarted:
+			gameStarted = true
+			fmt.Printf("Game %s started, playing %s against %s\n", message.GameId, message.Side, message.Opponent)
+			gameState.Register(newBotStateConsumer(message.Side, player, bot.thinkTime, moveChannel))
+		default:
+			event, err := convertToEvent(message)
+			if err != nil {
+				fmt.Printf("Failed to convert to an Event %s\n", err.Error())
+				continue
+			}
+			gameState.SendEvent(event)
+		}
+	}
+
+	if scanner.Err() != nil {
+		return scanner.Err()
+	}
+	return errors.New("the server closed the connection")
+}
+
+func main() {
+	server := flag.String("server", "localhost:9090", "address of the reversi server")
+	name := flag.String("name", "", "registered player name of the bot")
+	password := flag.String("password", "", "password of the bot")
+	register := flag.Bool("register", false, "register the name and password before the first game")
+	timeControl := flag.String("time-control", "", "only be matched with players who want the same time control")
+	strategyName := flag.String("strategy", "search", "how the bot picks moves: random, greedy, corners, search or mcts")
+	thinkTime := flag.Duration("think", time.Second, "longest time the bot thinks about a move")
+	games := flag.Int("games", 1, "number of games to play one after another, 0 keeps playing")
+	seed := flag.Int64("seed", 0, "seed for the random choices, 0 picks one from the clock")
+	bookFile := flag.String("book", "", "opening book to play from before the strategy takes over")
+	bookGames := flag.Int("book-games", 2, "games a book move needs to have been played in to be chosen")
+	weightsFile := flag.String("weights", "", "trained pattern weights the search strategy evaluates positions with")
+	workers := flag.Int("workers", 0, "threads the search strategy uses, 0 uses every core")
+	tlsOptions := tcpimpl.ClientTLSOptions{}
+	flag.BoolVar(&tlsOptions.UseTLS, "tls", false, "connect with TLS, verifying the server against the system certificate authorities")
+	flag.StringVar(&tlsOptions.CAFile, "tls-ca", "", "PEM file with the certificate authority used to verify the server")
+	flag.StringVar(&tlsOptions.Fingerprint, "tls-fingerprint", "", "pinned SHA-256 fingerprint of the server certificate")
+	flag.BoolVar(&tlsOptions.Insecure, "tls-insecure", false, "use TLS without verifying the server certificate, for local self-signed testing only")
+	flag.Parse()
+
+	if *name == "" || *password == "" {
+		fmt.Println("a -name and -password are required")
+		return
+	}
+
+	strategy, err := engine.ParseStrategy(*strategyName)
+	if err != nil {
+		fmt.Printf("%s: %s\n", err.Error(), *strategyName)
+		return
+	}
+
+	bot := &bot{
+		server:     *server,
+		tlsOptions: tlsOptions,
+		strategy:   strategy,
+		thinkTime:  *thinkTime,
+		seed:       *seed,
+		bookGames:  *bookGames,
+		workers:    *workers,
+	}
+
+	if *weightsFile != "" {
+		bot.evaluator, err = engine.LoadPatternEvaluator(*weightsFile)
+		if err != nil {
+			fmt.Printf("failed to load the pattern weights: %s\n", err.Error())
+			return
+		}
+	}
+
+	if *bookFile != "" {
+		bot.book, err = book.Load(*bookFile)
+		if err != nil {
+			fmt.Printf("failed to load the opening book: %s\n", err.Error())
+			return
+		}
+	}
+
+	for played := 0; *games == 0 || played < *games; played++ {
+		if *register && played == 0 {
+			bot.handshake = tcpimpl.NewRegisterHandshake(*name, *password)
+		} else {
+			bot.handshake = tcpimpl.NewLoginHandshake(*name, *password)
+		}
+		bot.handshake.TimeControl = *timeControl
+
+		err := bot.play()
+		if err != nil {
+			fmt.Printf("Game failed: %s\n", strings.TrimSpace(err.Error()))
+			if strings.HasPrefix(err.Error(), "Login failed") {
+				return
+			}
+			time.Sleep(retryDelay)
+		}
+	}
+}
@@ -7,36 +7,185 @@ import (
 	"fmt"
 	"strconv"
 	reversi_core "reversi/core"
+	"reversi/engine"
+	"time"
 )
 
+const (
+	solveCommand    = "solve"
+	solveTime       = 5 * time.Second
+	hintCommand     = "hint"
+	metricsCommand  = "metrics"
+	positionCommand = "position"
+	hintDepth       = 6
+	hintTime        = 2 * time.Second
+	// hintLineLength is how many replies are shown after each hinted move.
+	hintLineLength = 3
+)
+
+func printEndgameHint(gameState reversi_core.GameState) {
+	solution, err := engine.Solve(gameState, engine.EndgameLimits{Time: solveTime})
+	if err == engine.ErrSolveTimeout {
+		fmt.Println("The endgame is too far away to solve, try again closer to the end")
+		return
+	}
+	if err != nil {
+		fmt.Printf("Failed to solve the endgame %s\n", err.Error())
+		return
+	}
+
+	outcome := "draw"
+	if solution.Margin > 0 {
+		outcome = fmt.Sprintf("win by %d", solution.Margin)
+	} else if solution.Margin < 0 {
+		outcome = fmt.Sprintf("lose by %d", -solution.Margin)
+	}
+
+	fmt.Printf("With perfect play %s will %s\n", solution.Move, outcome)
+}
+
 type clientStateConsumer struct {
 	side        reversi_core.Player
+	rated       bool
+	hints       bool
+	searcher    *engine.Searcher
 	moveChannel chan<- reversi_core.Coordinate
 }
 
-func (consumer *clientStateConsumer) StateUpdated(gameState reversi_core.GameState) {
-	if gameState.PlayerTurn == consumer.side {
-		fmt.Println("Your turn!")
+func formatHint(score int) string {
+	if margin, exact := engine.FinalMargin(score); exact {
+		return fmt.Sprintf("%+d discs, exact", margin)
+	}
+
+	return fmt.Sprintf("%+.1f discs", engine.ScoreInDiscs(score))
+}
+
+func formatLine(line []reversi_core.Coordinate) string {
+	if len(line) > hintLineLength {
+		line = line[:hintLineLength]
+	}
+
+	return reversi_core.FormatMoves(line)
+}
+
+// rankedMoves searches every move when hints are on, otherwise the moves are
+// listed in no particular order.
+func (consumer *clientStateConsumer) rankedMoves(gameState reversi_core.GameState) ([]reversi_core.Coordinate, []engine.Result) {
+	if consumer.hints {
+		if consumer.searcher == nil {
+			consumer.searcher = engine.NewSearcher(engine.NewWeightedSquareEvaluator())
+		}
+
+		results, err := consumer.searcher.MultiPV(gameState, engine.Limits{Depth: hintDepth, Time: hintTime}, engine.AllMoves)
+		if err == nil && len(results) == len(gameState.MoveOptions()) {
+			moves := []reversi_core.Coordinate{}
+			for _, result := range results {
+				moves = append(moves, result.Move)
+			}
+			return moves, results
+		}
+		fmt.Println("Failed to work out the hints")
+	}
+
+	moves := []reversi_core.Coordinate{}
+	for move := range gameState.MoveOptions() {
+		moves = append(moves, move)
+	}
+	return moves, nil
+}
+
+// listMoves numbers the moves, either the number or the square selects a
+// move.
+func (consumer *clientStateConsumer) listMoves(gameState reversi_core.GameState) map[string]reversi_core.Coordinate {
+	selectionMap := make(map[string]reversi_core.Coordinate)
 
-		selectionMap := make(map[string]reversi_core.Coordinate)
+	moves, results := consumer.rankedMoves(gameState)
+	for i, move := range moves {
+		indexString := strconv.Itoa(i + 1)
+		selectionMap[indexString] = move
+		selectionMap[move.String()] = move
 
-		index := 1
-		for move := range gameState.MoveOptions() {
-			indexString := strconv.Itoa(index)
-			selectionMap[indexString] = move
+		if results == nil {
+			fmt.Printf("possible move: %s -> %s\n", indexString, move)
+			continue
+		}
 
-			fmt.Printf("possible move: %d -> (%d, %d)\n", index, move.X, move.Y)
-			index = index + 1
+		best := ""
+		if results[i].Score == results[0].Score {
+			best = " <- best"
+		}
+		line := ""
+		if replies := results[i].PrincipalVariation[1:]; len(replies) > 0 {
+			line = ", then " + formatLine(replies)
 		}
+		fmt.Printf("possible move: %s -> %s %s%s%s\n", indexString, move, formatHint(results[i].Score), line, best)
+	}
+
+	if consumer.rated {
+		fmt.Printf("type %s to write the position down\n", positionCommand)
+	} else {
+		fmt.Printf("type %s to turn move hints on or off, %s for an endgame hint, %s to compare the positions of both sides, %s to write the position down\n", hintCommand, solveCommand, metricsCommand, positionCommand)
+	}
+
+	return selectionMap
+}
+
+// allowsHints is false in rated games, the rating should be the player's own.
+func (consumer *clientStateConsumer) allowsHints() bool {
+	if consumer.rated {
+		fmt.Println("Hints are not available in rated games")
+		return false
+	}
+
+	return true
+}
+
+func (consumer *clientStateConsumer) toggleHints() bool {
+	if !consumer.allowsHints() {
+		return false
+	}
+
+	consumer.hints = !consumer.hints
+	if consumer.hints {
+		fmt.Println("Hints are on")
+	} else {
+		fmt.Println("Hints are off")
+	}
+	return true
+}
+
+func (consumer *clientStateConsumer) StateUpdated(gameState reversi_core.GameState) {
+	if gameState.IsOver() {
+		return
+	}
+
+	if gameState.PlayerTurn == consumer.side {
+		fmt.Println("Your turn!")
+
+		selectionMap := consumer.listMoves(gameState)
 
 		reader := bufio.NewReader(os.Stdin)
 
 		for {
-			fmt.Print("please enter a move -> ")
+			fmt.Print("please enter a move, such as e3, or its number -> ")
 			text, _ := reader.ReadString('\n')
-			text = strings.Replace(text, "\n", "", -1)
+			text = strings.ToLower(strings.TrimSpace(text))
 
-			if selected, found := selectionMap[text]; found {
+			if text == solveCommand {
+				if consumer.allowsHints() {
+					printEndgameHint(gameState)
+				}
+			} else if text == metricsCommand {
+				if consumer.allowsHints() {
+					PrintMetrics(gameState)
+				}
+			} else if text == positionCommand {
+				fmt.Println(gameState.Encode())
+			} else if text == hintCommand {
+				if consumer.toggleHints() {
+					selectionMap = consumer.listMoves(gameState)
+				}
+			} else if selected, found := selectionMap[text]; found {
 				consumer.moveChannel <- selected
 				break
 			} else {
@@ -49,9 +198,12 @@ func (consumer *clientStateConsumer) StateUpdated(gameState reversi_core.GameSta
 	}
 }
 
-func NewClientStateConsumer(side reversi_core.Player, moveChannel chan<- reversi_core.Coordinate) reversi_core.StateUpdateConsumer {
+// NewClientStateConsumer prompts for the player's moves, hints can only be
+// turned on when the game is not rated.
+func NewClientStateConsumer(side reversi_core.Player, rated bool, moveChannel chan<- reversi_core.Coordinate) reversi_core.StateUpdateConsumer {
 	return &clientStateConsumer{
 		side:        side,
+		rated:       rated,
 		moveChannel: moveChannel,
 	}
 }
@@ -39,12 +39,12 @@ func (consumer printStateConsumer) StateUpdated(gameState reversi_core.GameState
 
 	header := "   "
 	for x := range bounds {
-		header = header + fmt.Sprintf(" %d ", x)
+		header = header + fmt.Sprintf(" %c ", 'a'+x)
 	}
 	fmt.Println(header)
 
 	for y := range bounds {
-		rowString := fmt.Sprintf(" %d ", y)
+		rowString := fmt.Sprintf(" %d ", y+1)
 		for x := range bounds {
 			next := "[?]"
 			coordinate := reversi_core.Coordinate{X: x, Y: y}
@@ -72,3 +72,28 @@ func (consumer printStateConsumer) StateUpdated(gameState reversi_core.GameState
 func NewPrintStateConsumer() reversi_core.StateUpdateConsumer {
 	return printStateConsumer{}
 }
+
+// PrintMetrics compares the positions of both sides.
+func PrintMetrics(gameState reversi_core.GameState) {
+	black := gameState.Metrics(reversi_core.BLACK)
+	white := gameState.Metrics(reversi_core.WHITE)
+
+	rows := []struct {
+		name  string
+		black int
+		white int
+	}{
+		{"mobility", black.Mobility, white.Mobility},
+		{"potential mobility", black.PotentialMobility, white.PotentialMobility},
+		{"frontier discs", black.FrontierDiscs, white.FrontierDiscs},
+		{"stable discs", black.StableDiscs, white.StableDiscs},
+		{"corners", black.Corners, white.Corners},
+		{"X-squares", black.XSquares, white.XSquares},
+		{"C-squares", black.CSquares, white.CSquares},
+	}
+
+	fmt.Printf("%-20s %6s %6s\n", "", reversi_core.BLACK, reversi_core.WHITE)
+	for _, row := range rows {
+		fmt.Printf("%-20s %6d %6d\n", row.name, row.black, row.white)
+	}
+}
@@ -0,0 +1,88 @@
+package core
+
+import (
+	"fmt"
+	reversi_core "reversi/core"
+	"reversi/ratings"
+	"reversi/tcpimpl"
+	"time"
+)
+
+func displayName(name string, side reversi_core.Player) string {
+	if name == "" {
+		return side.String()
+	}
+
+	return fmt.Sprintf("%s (%s)", name, side)
+}
+
+func PrintSpectating(spectating tcpimpl.Spectating) {
+	fmt.Printf("Watching game [%s]\n", spectating.GameId)
+	fmt.Printf("X -> %s\n", displayName(spectating.Players[reversi_core.BLACK], reversi_core.BLACK))
+	fmt.Printf("0 -> %s\n", displayName(spectating.Players[reversi_core.WHITE], reversi_core.WHITE))
+
+	PrintClocks(spectating.Clocks)
+}
+
+func PrintClocks(clocks tcpimpl.Clocks) {
+	fmt.Printf(
+		"clocks: BLACK %s | WHITE %s\n",
+		clocks[reversi_core.BLACK].Round(time.Second),
+		clocks[reversi_core.WHITE].Round(time.Second),
+	)
+}
+
+func PrintGameOver(gameOver tcpimpl.GameOver) {
+	black := displayName(gameOver.Players[reversi_core.BLACK], reversi_core.BLACK)
+	white := displayName(gameOver.Players[reversi_core.WHITE], reversi_core.WHITE)
+
+	fmt.Println("Game over!")
+	if gameOver.Forfeit != "" {
+		fmt.Printf("%s left the game\n", displayName(gameOver.Players[gameOver.Forfeit], gameOver.Forfeit))
+	}
+	fmt.Printf("%s %d - %d %s\n", black, gameOver.Discs[reversi_core.BLACK], gameOver.Discs[reversi_core.WHITE], white)
+
+	if gameOver.Winner == "" {
+		fmt.Println("The game is a draw")
+	} else {
+		fmt.Printf("%s wins\n", displayName(gameOver.Players[gameOver.Winner], gameOver.Winner))
+	}
+
+	for _, side := range []reversi_core.Player{reversi_core.BLACK, reversi_core.WHITE} {
+		change, rated := gameOver.RatingChanges[side]
+		if rated {
+			fmt.Printf("%s rating: %.0f -> %.0f (%+.0f)\n", change.Name, change.Before, change.After, change.After-change.Before)
+		}
+	}
+}
+
+func PrintLeaderboard(standings []ratings.PlayerRating) {
+	if len(standings) == 0 {
+		fmt.Println("No rated games have been played yet")
+		return
+	}
+
+	fmt.Printf("%4s  %-20s %7s %5s %6s %6s %6s\n", "#", "name", "rating", "+/-", "wins", "losses", "draws")
+	for i, standing := range standings {
+		fmt.Printf(
+			"%4d  %-20s %7.0f %5.0f %6d %6d %6d\n",
+			i+1,
+			standing.Name,
+			standing.Rating.Rating,
+			2*standing.Deviation,
+			standing.Wins,
+			standing.Losses,
+			standing.Draws,
+		)
+	}
+}
+
+func PrintQueueStatus(status tcpimpl.QueueStatus) {
+	fmt.Printf(
+		"Waiting for an opponent: position %d in the queue, waiting for %s, matching ratings %.0f +/- %.0f\n",
+		status.Position,
+		status.Waiting.Round(time.Second),
+		status.Rating,
+		status.RatingWindow,
+	)
+}
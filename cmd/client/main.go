@@ -1,44 +1,100 @@
 package main
 
 import (
+	"bufio"
+	"crypto/tls"
 	"encoding/json"
+	"flag"
 	"fmt"
-	"errors"
 	"net"
+	"os"
 	"reversi/cmd/client/core"
 	reversi_core "reversi/core"
+	"reversi/ratings"
 	"reversi/tcpimpl"
+	"strings"
 )
 
-func convertToInt(raw interface{}) (error, int) {
-	t := fmt.Sprintf("%T", raw)
+type incomingMessage struct {
+	EventType        reversi_core.EventType
+	NotificationType tcpimpl.NotificationType
+	Side             reversi_core.Player
+	GameId           string
+	Opponent         string
+	TimeControl      string
+	Rated            bool
+	Data             json.RawMessage
+}
+
+func convertToEvent(message incomingMessage) (reversi_core.Event, error) {
+	if message.EventType == reversi_core.MOVED {
+		coordinate := reversi_core.Coordinate{}
+		err := json.Unmarshal(message.Data, &coordinate)
+		if err != nil {
+			return reversi_core.Event{}, err
+		}
 
-	if t == "float64" {
-		return nil, int(raw.(float64))
+		return reversi_core.NewMoveEvent(coordinate), nil
 	}
 
-	return errors.New("failed to convert the value to an int :("), 0
+	return reversi_core.Event{EventType: message.EventType}, nil
 }
 
-func convertToMovedEvent(rawEvent reversi_core.Event) (error, reversi_core.Event) {
-	rawMap := rawEvent.Data.(map[string]interface{})
-
-	xErr, x := convertToInt(rawMap["X"])
-	yErr, y := convertToInt(rawMap["Y"])
-
-	emptyEvent := reversi_core.Event{}
-	if xErr != nil {
-		return xErr, emptyEvent
-	}
-	if yErr != nil {
-		return yErr, emptyEvent
+func handleNotification(message incomingMessage) bool {
+	switch message.NotificationType {
+	case tcpimpl.LOGGED_IN:
+		name := ""
+		json.Unmarshal(message.Data, &name)
+		fmt.Printf("Logged in as %s, waiting for an opponent\n", name)
+	case tcpimpl.QUEUED:
+		status := tcpimpl.QueueStatus{}
+		err := json.Unmarshal(message.Data, &status)
+		if err != nil {
+			fmt.Printf("Failed to read the queue status %s\n", err.Error())
+			return false
+		}
+		core.PrintQueueStatus(status)
+	case tcpimpl.GAME_OVER:
+		gameOver := tcpimpl.GameOver{}
+		err := json.Unmarshal(message.Data, &gameOver)
+		if err != nil {
+			fmt.Printf("Failed to read the result %s\n", err.Error())
+			return true
+		}
+		core.PrintGameOver(gameOver)
+		return true
+	case tcpimpl.STANDINGS:
+		standings := []ratings.PlayerRating{}
+		err := json.Unmarshal(message.Data, &standings)
+		if err != nil {
+			fmt.Printf("Failed to read the leaderboard %s\n", err.Error())
+			return true
+		}
+		core.PrintLeaderboard(standings)
+		return true
+	case tcpimpl.SPECTATING:
+		spectating := tcpimpl.Spectating{}
+		err := json.Unmarshal(message.Data, &spectating)
+		if err != nil {
+			fmt.Printf("Failed to read the game details %s\n", err.Error())
+			return false
+		}
+		core.PrintSpectating(spectating)
+	case tcpimpl.CLOCKS:
+		clocks := tcpimpl.Clocks{}
+		err := json.Unmarshal(message.Data, &clocks)
+		if err != nil {
+			fmt.Printf("Failed to read the clocks %s\n", err.Error())
+			return false
+		}
+		core.PrintClocks(clocks)
 	}
 
-	return nil, reversi_core.NewMoveEvent(reversi_core.Coordinate{X: x, Y: y})
+	return false
 }
 
-func listen(connection net.Conn, c chan<- bool, ended chan<- bool, moveChannel chan<- reversi_core.Coordinate) {
-	buffer := make([]byte, 1024)
+func listen(connection net.Conn, watching bool, c chan<- bool, ended chan<- bool, moveChannel chan<- reversi_core.Coordinate) {
+	scanner := bufio.NewScanner(connection)
 
 	signaled := false
 	gameStarted := false
@@ -46,36 +102,40 @@ func listen(connection net.Conn, c chan<- bool, ended chan<- bool, moveChannel c
 	gameState := reversi_core.NewGameEventAggregator()
 	gameState.Register(core.NewPrintStateConsumer())
 
-	for {
-		readBytes, err := connection.Read(buffer)
-		if err != nil {
-			fmt.Errorf("failed to connect: %s", err.Error())
-		}
+	for scanner.Scan() {
+		line := scanner.Text()
 
-		if readBytes < 1 {
-			fmt.Println("Connection must have been closed :(")
-			break
+		message := incomingMessage{}
+		err := json.Unmarshal([]byte(line), &message)
+		if err != nil {
+			fmt.Println(line)
+			if gameStarted {
+				gameState.SendEvent(reversi_core.Event{})
+			}
+			continue
 		}
 
-		if !gameStarted {
-			sideAssigned := tcpimpl.SideAssigned{}
-			json.Unmarshal(buffer[:readBytes], &sideAssigned)
-
+		if message.NotificationType != "" {
+			if handleNotification(message) {
+				break
+			}
+		} else if !gameStarted && !watching {
 			gameStarted = true
-			fmt.Printf("Game has started and you have been assigned side ->  [%s]\n", sideAssigned.Side)
+			fmt.Printf("Game [%s] has started and you have been assigned side ->  [%s]\n", message.GameId, message.Side)
+			fmt.Printf("Your opponent is %s\n", message.Opponent)
+			if message.TimeControl != "" {
+				fmt.Printf("Time control: %s\n", message.TimeControl)
+			}
+			if message.Rated {
+				fmt.Println("This game is rated, hints are turned off")
+			}
 
-			gameState.Register(core.NewClientStateConsumer(sideAssigned.Side, moveChannel))
+			gameState.Register(core.NewClientStateConsumer(message.Side, message.Rated, moveChannel))
 		} else {
-			event := reversi_core.Event{}
-
-			json.Unmarshal(buffer[:readBytes], &event)
-
-			if event.EventType == reversi_core.MOVED {
-				err, event = convertToMovedEvent(event)
-				if err != nil {
-					fmt.Errorf("Failed to convert to MovedEvent %s\n", err.Error())
-					continue
-				}
+			event, err := convertToEvent(message)
+			if err != nil {
+				fmt.Printf("Failed to convert to an Event %s\n", err.Error())
+				continue
 			}
 
 			gameState.SendEvent(event)
@@ -87,6 +147,9 @@ func listen(connection net.Conn, c chan<- bool, ended chan<- bool, moveChannel c
 		}
 	}
 
+	if scanner.Err() != nil {
+		fmt.Println("Connection must have been closed :(")
+	}
 	ended <- true
 }
 
@@ -97,27 +160,103 @@ func reply(connection net.Conn, c <-chan bool, moveChannel <-chan reversi_core.C
 		move := <-moveChannel
 		data, err := json.Marshal(move)
 		if err != nil {
-			fmt.Errorf("Error serializing move %s\n", err.Error())
+			fmt.Printf("Error serializing move %s\n", err.Error())
 		}
 
-		_, err = connection.Write(data)
+		_, err = connection.Write(append(data, '\n'))
 		if err != nil {
-			fmt.Errorf("Error writing data %s", err.Error())
+			fmt.Printf("Error writing data %s\n", err.Error())
 		}
 	}
 }
 
+func promptForPassword() string {
+	fmt.Print("password -> ")
+	text, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	return strings.TrimSpace(text)
+}
+
+func sendHandshake(connection net.Conn, handshake tcpimpl.Handshake) error {
+	data, err := json.Marshal(handshake)
+	if err != nil {
+		return err
+	}
+
+	_, err = connection.Write(append(data, '\n'))
+	return err
+}
+
+func dial(server string, options tcpimpl.ClientTLSOptions) (net.Conn, error) {
+	if !options.Enabled() {
+		return net.Dial("tcp", server)
+	}
+
+	config, err := tcpimpl.NewClientTLSConfig(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return tls.Dial("tcp", server, config)
+}
+
 func main() {
-	connection, err := net.Dial("tcp", "localhost:9090")
+	server := flag.String("server", "localhost:9090", "address of the reversi server")
+	name := flag.String("name", "", "your registered player name")
+	password := flag.String("password", "", "your password, prompted for when not provided")
+	register := flag.Bool("register", false, "register the name and password before playing")
+	timeControl := flag.String("time-control", "", "only be matched with players who want the same time control, for example 5+0")
+	computer := flag.String("computer", "", "play against the server's computer instead of waiting for a person: a level from beginner, novice, casual, intermediate, advanced to expert, or random, greedy, corners, search or mcts")
+	watch := flag.String("watch", "", "id of a game to watch instead of play")
+	leaderboard := flag.Bool("leaderboard", false, "show the rating leaderboard instead of playing")
+	tlsOptions := tcpimpl.ClientTLSOptions{}
+	flag.BoolVar(&tlsOptions.UseTLS, "tls", false, "connect with TLS, verifying the server against the system certificate authorities")
+	flag.StringVar(&tlsOptions.CAFile, "tls-ca", "", "PEM file with the certificate authority used to verify the server")
+	flag.StringVar(&tlsOptions.Fingerprint, "tls-fingerprint", "", "pinned SHA-256 fingerprint of the server certificate")
+	flag.BoolVar(&tlsOptions.Insecure, "tls-insecure", false, "use TLS without verifying the server certificate, for local self-signed testing only")
+	flag.Parse()
+
+	connection, err := dial(*server, tlsOptions)
+	if err != nil {
+		fmt.Printf("failed to connect: %s\n", err.Error())
+		return
+	}
+
+	watching := strings.TrimSpace(*watch) != ""
+
+	var handshake tcpimpl.Handshake
+	if *leaderboard {
+		handshake = tcpimpl.NewLeaderboardHandshake()
+	} else if watching {
+		handshake = tcpimpl.NewWatchHandshake(strings.TrimSpace(*watch))
+	} else {
+		if *name == "" {
+			fmt.Println("a -name is required to play")
+			return
+		}
+		if *password == "" {
+			*password = promptForPassword()
+		}
+
+		if *register {
+			handshake = tcpimpl.NewRegisterHandshake(*name, *password)
+		} else {
+			handshake = tcpimpl.NewLoginHandshake(*name, *password)
+		}
+		handshake.TimeControl = *timeControl
+		handshake.Computer = strings.TrimSpace(*computer)
+	}
+
+	err = sendHandshake(connection, handshake)
 	if err != nil {
-		fmt.Errorf("failed to connect: %s", err.Error())
+		fmt.Printf("failed to send the handshake: %s\n", err.Error())
+		return
 	}
 
 	startedChannel := make(chan bool)
 	endedChannel := make(chan bool)
 	moveChannel := make(chan reversi_core.Coordinate)
 
-	go listen(connection, startedChannel, endedChannel, moveChannel)
+	go listen(connection, watching, startedChannel, endedChannel, moveChannel)
 	go reply(connection, startedChannel, moveChannel)
 
 	_ = <-endedChannel
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"reversi/ggf"
+	"reversi/ratings"
+	"reversi/records"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ratingOf is the player's current rating, 0 leaves it out of the game.
+func ratingOf(standings *ratings.Store, name string) float64 {
+	if standings == nil {
+		return 0
+	}
+
+	player := standings.Get(name)
+	if player.Games == 0 {
+		return 0
+	}
+
+	return player.Rating.Rating
+}
+
+func export(store *records.Store, standings *ratings.Store, gameId string, path string) error {
+	games, err := store.ReadAll()
+	if err != nil {
+		return err
+	}
+
+	var text strings.Builder
+	exported := 0
+	for _, record := range games {
+		if gameId != "" && record.GameId != gameId {
+			continue
+		}
+
+		game, err := ggf.FromRecord(record, ratingOf(standings, record.Black), ratingOf(standings, record.White))
+		if err != nil {
+			return fmt.Errorf("game %s: %w", record.GameId, err)
+		}
+		text.WriteString(game.String() + "\n")
+		exported++
+	}
+
+	if gameId != "" && exported == 0 {
+		return fmt.Errorf("no game found with id %s", gameId)
+	}
+
+	if path == "-" {
+		fmt.Print(text.String())
+		return nil
+	}
+	if err := ioutil.WriteFile(path, []byte(text.String()), 0644); err != nil {
+		return err
+	}
+
+	fmt.Printf("Exported %d games to %s\n", exported, path)
+	return nil
+}
+
+// importGames records every finished game of the file, games that cannot be
+// recorded are reported and skipped.
+func importGames(store *records.Store, path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	games, err := ggf.ParseAll(string(data))
+	if err != nil {
+		return err
+	}
+
+	imported := 0
+	for i, game := range games {
+		record, err := game.Record(uuid.New().String())
+		if err != nil {
+			fmt.Printf("Skipped game %d: %s\n", i+1, err.Error())
+			continue
+		}
+
+		if err := store.Append(record); err != nil {
+			return err
+		}
+		imported++
+	}
+
+	fmt.Printf("Imported %d of %d games\n", imported, len(games))
+	return nil
+}
+
+func main() {
+	gamesFile := flag.String("games", "games.jsonl", "file where the server recorded the games")
+	exportFile := flag.String("export", "", "GGF file to write the recorded games to, - for the standard output")
+	gameId := flag.String("game", "", "id of the only game to export")
+	ratingsFile := flag.String("ratings", "", "file of player ratings, their current ratings are added to the exported games")
+	importFile := flag.String("import", "", "GGF file whose finished games are added to -games")
+	flag.Parse()
+
+	if (*exportFile == "") == (*importFile == "") {
+		fmt.Println("either -export or -import must be given")
+		return
+	}
+
+	store := records.NewStore(*gamesFile)
+	if *importFile != "" {
+		if err := importGames(store, *importFile); err != nil {
+			fmt.Printf("failed to import %s: %s\n", *importFile, err.Error())
+		}
+		return
+	}
+
+	var standings *ratings.Store
+	if *ratingsFile != "" {
+		var err error
+		if standings, err = ratings.NewStore(*ratingsFile); err != nil {
+			fmt.Printf("unable to load the ratings: %s\n", err.Error())
+			return
+		}
+	}
+
+	if err := export(store, standings, strings.TrimSpace(*gameId), *exportFile); err != nil {
+		fmt.Printf("failed to export the games: %s\n", err.Error())
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"reversi/core"
+	"sort"
+	"time"
+)
+
+// positionAfter plays the moves from the starting position, refusing any that
+// is not legal.
+func positionAfter(moves []core.Coordinate) (core.GameState, error) {
+	states, err := core.Replay(core.StartingPosition(), moves)
+	if err != nil {
+		return core.GameState{}, err
+	}
+
+	return states[len(states)-1], nil
+}
+
+func printDivide(gameState core.GameState, depth int) {
+	started := time.Now()
+	counts := core.Divide(gameState, depth)
+
+	moves := []core.Coordinate{}
+	for move := range counts {
+		moves = append(moves, move)
+	}
+	sort.Slice(moves, func(i, j int) bool {
+		if moves[i].Y != moves[j].Y {
+			return moves[i].Y < moves[j].Y
+		}
+		return moves[i].X < moves[j].X
+	})
+
+	var total uint64
+	for _, move := range moves {
+		fmt.Printf("%s %d\n", move, counts[move])
+		total += counts[move]
+	}
+	fmt.Printf("%d moves, %d leaves in %s\n", len(moves), total, time.Since(started).Round(time.Millisecond))
+}
+
+func main() {
+	depth := flag.Int("depth", 6, "plies to count to, a pass counts as a ply")
+	openingMoves := flag.String("moves", "", "moves played from the starting position first, such as c4 c3")
+	position := flag.String("position", "", "count from this position instead, 64 squares of X, O or - and the side to move")
+	divide := flag.Bool("divide", false, "count the leaves under each move of the position at -depth")
+	flag.Parse()
+
+	var gameState core.GameState
+	if *position != "" {
+		parsed, err := core.ParsePosition(*position)
+		if err != nil {
+			fmt.Printf("failed to read the position: %s\n", err.Error())
+			return
+		}
+		gameState = parsed
+	} else {
+		moves, err := core.ParseMoves(*openingMoves)
+		if err != nil {
+			fmt.Printf("failed to read the moves: %s\n", err.Error())
+			return
+		}
+
+		gameState, err = positionAfter(moves)
+		if err != nil {
+			fmt.Printf("failed to reach the position: %s\n", err.Error())
+			return
+		}
+	}
+
+	if *divide {
+		printDivide(gameState, *depth)
+		return
+	}
+
+	for ply := 1; ply <= *depth; ply++ {
+		started := time.Now()
+		leaves := core.Perft(gameState, ply)
+		fmt.Printf("depth %d: %d leaves in %s\n", ply, leaves, time.Since(started).Round(time.Millisecond))
+	}
+}
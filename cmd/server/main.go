@@ -1,35 +1,85 @@
 package main
 
 import (
+	"crypto/tls"
+	"flag"
 	"log"
 	"net"
+	"reversi/accounts"
+	"reversi/engine"
+	"reversi/nboard"
+	"reversi/ratings"
+	"reversi/records"
 	"reversi/tcpimpl"
+	"strings"
 )
 
-func listen(listener net.Listener) {
-	pendingGame := tcpimpl.NewPendingGame()
-
+func listen(listener net.Listener, lobby *tcpimpl.Lobby) {
 	for {
 		conn, err := listener.Accept()
 		if err != nil {
 			log.Printf("unable to accept connections: %s", err.Error())
+			continue
 		}
 
-		pendingGame.AddPlayer(conn)
+		go lobby.Admit(conn)
+	}
+}
 
-		if pendingGame.IsFull() {
-			activeGame := tcpimpl.NewActiveGame(pendingGame)
+func openListener(address string, certFile string, keyFile string) (net.Listener, error) {
+	if certFile == "" && keyFile == "" {
+		return net.Listen("tcp", address)
+	}
 
-			go activeGame.Start()
-		}
+	config, err := tcpimpl.NewServerTLSConfig(certFile, keyFile)
+	if err != nil {
+		return nil, err
 	}
+
+	log.Printf("serving TLS with certificate fingerprint %s", tcpimpl.Fingerprint(config.Certificates[0].Certificate[0]))
+
+	return tls.Listen("tcp", address, config)
 }
 
 func main() {
-	listener, err := net.Listen("tcp", ":9090")
+	address := flag.String("address", ":9090", "address to listen on")
+	certFile := flag.String("tls-cert", "", "PEM certificate file, enables TLS together with -tls-key")
+	keyFile := flag.String("tls-key", "", "PEM private key file for -tls-cert")
+	usersFile := flag.String("users", "users.json", "file where registered players are stored")
+	gamesFile := flag.String("games", "games.jsonl", "file where finished games are recorded")
+	ratingsFile := flag.String("ratings", "ratings.json", "file where player ratings are stored")
+	nboardCommand := flag.String("nboard-engine", "", "command starting an engine that speaks the NBoard protocol, offered as a computer opponent")
+	nboardName := flag.String("nboard-name", "nboard", "name players ask for to play the -nboard-engine")
+	nboardDepth := flag.Int("nboard-depth", 0, "search depth given to the -nboard-engine, 0 leaves it to the engine")
+	flag.Parse()
+
+	if (*certFile == "") != (*keyFile == "") {
+		log.Fatal("-tls-cert and -tls-key must be provided together")
+	}
+
+	listener, err := openListener(*address, *certFile, *keyFile)
 	if err != nil {
 		log.Fatalf("unable to start server: %s", err.Error())
 	}
 
-	listen(listener)
+	users, err := accounts.NewUserStore(*usersFile)
+	if err != nil {
+		log.Fatalf("unable to load the players: %s", err.Error())
+	}
+
+	standings, err := ratings.NewStore(*ratingsFile)
+	if err != nil {
+		log.Fatalf("unable to load the ratings: %s", err.Error())
+	}
+
+	lobby := tcpimpl.NewLobby(users, records.NewStore(*gamesFile), standings)
+
+	if *nboardCommand != "" {
+		options := nboard.Options{Command: strings.Fields(*nboardCommand), Depth: *nboardDepth}
+		lobby.AddComputer(*nboardName, func() (engine.Player, error) {
+			return nboard.Start(options)
+		})
+	}
+
+	listen(listener, lobby)
 }
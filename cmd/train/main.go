@@ -0,0 +1,119 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"reversi/core"
+	"reversi/engine"
+	"reversi/records"
+	"time"
+)
+
+// selfPlay plays a game between two searches, opening with a few random
+// moves so the games differ.
+func selfPlay(player engine.Player, random engine.Player, randomMoves int, depth int) ([]core.Coordinate, error) {
+	state := core.StartingPosition()
+
+	moves := []core.Coordinate{}
+	for !state.IsOver() {
+		chooser := player
+		if len(moves) < randomMoves {
+			chooser = random
+		}
+
+		result, err := chooser.BestMove(state, engine.Limits{Depth: depth})
+		if err != nil {
+			return nil, err
+		}
+
+		states, err := core.Replay(state, []core.Coordinate{result.Move})
+		if err != nil {
+			return nil, err
+		}
+
+		moves = append(moves, result.Move)
+		state = states[1]
+	}
+
+	return moves, nil
+}
+
+func recordedGames(path string) ([][]core.Coordinate, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	games, err := records.NewStore(path).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	result := [][]core.Coordinate{}
+	for _, game := range games {
+		if game.Forfeit == "" {
+			result = append(result, game.Moves)
+		}
+	}
+
+	return result, nil
+}
+
+func main() {
+	gamesFile := flag.String("games", "games.jsonl", "file where the server recorded the games, empty to train on self-play only")
+	selfPlayGames := flag.Int("self-play", 0, "number of games the search plays against itself to train on as well")
+	selfPlayDepth := flag.Int("self-play-depth", 2, "search depth of the self-play games")
+	randomMoves := flag.Int("random-moves", 8, "random moves at the start of each self-play game")
+	out := flag.String("out", "weights.json", "file the pattern weights are written to")
+	phases := flag.Int("phases", engine.DefaultPhases, "number of game phases with their own weights")
+	epochs := flag.Int("epochs", 10, "passes over the training positions")
+	rate := flag.Float64("rate", 0.2, "share of the error corrected at every position")
+	seed := flag.Int64("seed", 0, "seed for the random choices, 0 picks one from the clock")
+	flag.Parse()
+
+	games, err := recordedGames(*gamesFile)
+	if err != nil {
+		fmt.Printf("failed to read the games: %s\n", err.Error())
+		return
+	}
+	fmt.Printf("%d recorded games\n", len(games))
+
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	random, _ := engine.NewPlayer(engine.RANDOM, rand.New(rand.NewSource(*seed)).Int63())
+	searcher := engine.NewSearcher(engine.NewWeightedSquareEvaluator())
+	for i := 0; i < *selfPlayGames; i++ {
+		moves, err := selfPlay(searcher, random, *randomMoves, *selfPlayDepth)
+		if err != nil {
+			fmt.Printf("self-play failed: %s\n", err.Error())
+			return
+		}
+		games = append(games, moves)
+	}
+	if *selfPlayGames > 0 {
+		fmt.Printf("%d self-play games\n", *selfPlayGames)
+	}
+
+	if len(games) == 0 {
+		fmt.Println("there are no games to train on")
+		return
+	}
+
+	options := engine.TrainingOptions{Phases: *phases, Epochs: *epochs, LearningRate: *rate, Seed: *seed}
+	weights, err := engine.TrainPatterns(games, options, func(epoch int, meanSquaredError float64) {
+		fmt.Printf("epoch %d: mean squared error %.2f\n", epoch, meanSquaredError)
+	})
+	if err != nil {
+		fmt.Printf("failed to train the weights: %s\n", err.Error())
+		return
+	}
+
+	err = weights.Save(*out)
+	if err != nil {
+		fmt.Printf("failed to save the weights: %s\n", err.Error())
+		return
+	}
+
+	fmt.Printf("Wrote the weights to %s\n", *out)
+}
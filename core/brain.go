@@ -27,7 +27,7 @@ func (brain *gameBrain) ExecuteCommand(move Command, rejectHandler CommandReject
 	brain.commandHandler.AttemptCommand(move, rejectHandler)
 }
 
-func NewGameBrain(eventConsumer EventConsumer) GameBrain {
+func newGameBrain(eventConsumer EventConsumer, stateUpdateConsumers ...StateUpdateConsumer) GameBrain {
 	gameEventAggregator := NewGameEventAggregator()
 	commandHandler := NewCommandHandler(
 		&multipleConsumerAdapter{
@@ -36,7 +36,19 @@ func NewGameBrain(eventConsumer EventConsumer) GameBrain {
 		gameEventAggregator,
 	)
 
-	gameEventAggregator.Register(NewPrintStateConsumer())
+	for _, consumer := range stateUpdateConsumers {
+		gameEventAggregator.Register(consumer)
+	}
 
 	return &gameBrain{commandHandler: commandHandler}
 }
+
+func NewGameBrain(eventConsumer EventConsumer) GameBrain {
+	return newGameBrain(eventConsumer, NewPrintStateConsumer())
+}
+
+// NewQuietGameBrain does not print the board after every move, for running
+// many games at once.
+func NewQuietGameBrain(eventConsumer EventConsumer) GameBrain {
+	return newGameBrain(eventConsumer)
+}
@@ -392,3 +392,18 @@ func Test_Entire_SequenceOfMovesForAGame(t *testing.T) {
 		t.Error("All moves should have been valid")
 	}
 }
+
+func Test_QuietGameBrain_acceptsTheSameMoves(t *testing.T) {
+	testEventConsumer := NewTestEventConsumer()
+
+	brain := core.NewQuietGameBrain(&testEventConsumer)
+	testRejectHandler := NewTestCommandRejectHandler()
+
+	brain.Initialize(&testRejectHandler)
+	brain.ExecuteCommand(core.NewMoveCommand(core.BLACK, core.Coordinate{X: 2, Y: 4}), &testRejectHandler)
+	brain.ExecuteCommand(core.NewMoveCommand(core.BLACK, core.Coordinate{X: 2, Y: 5}), &testRejectHandler)
+
+	if len(testEventConsumer.events) != 2 || !testRejectHandler.rejectWasCalled {
+		t.Error("Expected the first move to be played and the second, out of turn, to be rejected")
+	}
+}
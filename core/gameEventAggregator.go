@@ -1,5 +1,7 @@
 package core
 
+import "fmt"
+
 type Player string
 
 const (
@@ -37,6 +39,25 @@ func NewInitializedEvent() Event {
 	}
 }
 
+// NewInitializedFromEvent starts the game from the position instead of the
+// usual one. The position is read back from Encode, so the game goes on from
+// exactly what is on the board, and one without a side to move is refused.
+func NewInitializedFromEvent(gameState GameState) (Event, error) {
+	if gameState.PlayerTurn != BLACK && gameState.PlayerTurn != WHITE {
+		return Event{}, fmt.Errorf("%w: %q is not a side to move", ErrBadPosition, gameState.PlayerTurn)
+	}
+
+	start, err := ParsePosition(gameState.Encode())
+	if err != nil {
+		return Event{}, err
+	}
+
+	return Event{
+		EventType: INITILIZED,
+		Data:      start,
+	}, nil
+}
+
 func NewMoveEvent(coordinate Coordinate) Event {
 	return Event{
 		EventType: MOVED,
@@ -163,6 +184,7 @@ func getInitialGameState() GameState {
 		Used:          used,
 		Edge:          edge,
 		PossibleMoves: possibleMoves,
+		Hash:          ComputeHash(board, initialSide),
 	}
 
 	return gameState
@@ -201,7 +223,7 @@ func possibleDirections(side Player, move Coordinate, board map[Coordinate]CellC
 		x := cell.X
 		y := cell.Y
 
-		if inBounds(cell) && board[cell] != nil && board[cell].OwnedBy(side.opposite()) {
+		if inBounds(cell) && board[cell] != nil && board[cell].OwnedBy(side.Opposite()) {
 			directions[directionCount] = Direction{
 				X: x - move.X,
 				Y: y - move.Y,
@@ -250,7 +272,7 @@ func possibleMovesFor(edge map[Coordinate]bool, board map[Coordinate]CellClaim,
 	}
 }
 
-func (player Player) opposite() Player {
+func (player Player) Opposite() Player {
 	if player == WHITE {
 		return BLACK
 	}
@@ -269,7 +291,7 @@ func matchingSide(side Player, coordinate Coordinate, board map[Coordinate]CellC
 }
 
 func oppositeClaim(side Player, coordinate Coordinate, board map[Coordinate]CellClaim) bool {
-	return matchingSide(side.opposite(), coordinate, board)
+	return matchingSide(side.Opposite(), coordinate, board)
 }
 
 func ownClaim(side Player, coordinate Coordinate, board map[Coordinate]CellClaim) bool {
@@ -386,14 +408,16 @@ func applyMove(gameState GameState, side Player, coordinate Coordinate) GameStat
 	cellsToFlip := getCellsToFlip(gameState.Board, coordinate, side)
 	for cell := range cellsToFlip {
 		gameState.Board[cell] = owner
+		gameState.Hash ^= ZobristKey(cell, side) ^ ZobristKey(cell, side.Opposite())
 	}
 	gameState.Board[coordinate] = owner
+	gameState.Hash ^= ZobristKey(coordinate, side)
 
 	gameState.Used[coordinate] = true
 	gameState.Edge = updateEdge(gameState.Edge, gameState.Used, coordinate)
 
 	// Try to get moves for the opposite side
-	possibleMoves := possibleMovesFor(gameState.Edge, gameState.Board, side.opposite())
+	possibleMoves := possibleMovesFor(gameState.Edge, gameState.Board, side.Opposite())
 	if len(possibleMoves.moves) == 0 {
 		// If there are no moves for the opposite side, get moves for the same side
 		possibleMoves = possibleMovesFor(gameState.Edge, gameState.Board, side)
@@ -401,6 +425,7 @@ func applyMove(gameState GameState, side Player, coordinate Coordinate) GameStat
 
 	gameState.PossibleMoves = possibleMoves
 	gameState.PlayerTurn = possibleMoves.side
+	gameState.Hash ^= TurnKey(side) ^ TurnKey(possibleMoves.side)
 
 	return gameState
 }
@@ -413,6 +438,9 @@ type gameEventAggregator struct {
 func (aggregator *gameEventAggregator) SendEvent(event Event) {
 	if event.EventType == INITILIZED {
 		aggregator.state = getInitialGameState()
+		if start, given := event.Data.(GameState); given {
+			aggregator.state = start.Copy()
+		}
 	}
 
 	if event.EventType == MOVED {
@@ -449,12 +477,73 @@ type GameState struct {
 	Used          map[Coordinate]bool
 	Edge          map[Coordinate]bool
 	PossibleMoves possibleMoves
+	// Hash is the Zobrist hash of the board and the side to move.
+	Hash uint64
 }
 
 func (gameState GameState) MoveOptions() map[Coordinate]bool {
 	return gameState.PossibleMoves.moves
 }
 
+func copyCoordinates(source map[Coordinate]bool) map[Coordinate]bool {
+	result := make(map[Coordinate]bool)
+	for coordinate, value := range source {
+		result[coordinate] = value
+	}
+
+	return result
+}
+
+// Copy returns a GameState that shares no maps with the original, the
+// aggregator updates the maps of the state it hands out as events arrive.
+func (gameState GameState) Copy() GameState {
+	board := make(map[Coordinate]CellClaim)
+	for coordinate, owner := range gameState.Board {
+		board[coordinate] = owner
+	}
+
+	return GameState{
+		Board:      board,
+		PlayerTurn: gameState.PlayerTurn,
+		Used:       copyCoordinates(gameState.Used),
+		Edge:       copyCoordinates(gameState.Edge),
+		PossibleMoves: possibleMoves{
+			side:  gameState.PossibleMoves.side,
+			moves: copyCoordinates(gameState.PossibleMoves.moves),
+		},
+		Hash: gameState.Hash,
+	}
+}
+
+func (gameState GameState) IsOver() bool {
+	return len(gameState.PossibleMoves.moves) == 0
+}
+
+func (gameState GameState) DiscCount(side Player) int {
+	count := 0
+	for _, owner := range gameState.Board {
+		if owner.OwnedBy(side) {
+			count++
+		}
+	}
+
+	return count
+}
+
+func (gameState GameState) Winner() (Player, bool) {
+	black := gameState.DiscCount(BLACK)
+	white := gameState.DiscCount(WHITE)
+
+	if black > white {
+		return BLACK, true
+	}
+	if white > black {
+		return WHITE, true
+	}
+
+	return "", false
+}
+
 type StateUpdaterAndEventConsumer interface {
 	StateUpdateSource
 	EventConsumer
@@ -1,6 +1,7 @@
 package core_test
 
 import (
+	"errors"
 	"fmt"
 	"reversi/core"
 	"testing"
@@ -193,3 +194,94 @@ func Test_MoveEvent_canPlayAWholeGame(t *testing.T) {
 }
 
 //Figure out how to test if a player has no moves and that turn gets skipped
+
+func Test_FinishedGame_reportsTheWinner(t *testing.T) {
+	testStateUpdateConsumer := newTestStateUpdateConsumer()
+
+	aggregator := core.NewGameEventAggregator()
+	aggregator.Register(&testStateUpdateConsumer)
+
+	aggregator.SendEvent(core.NewInitializedEvent())
+
+	if testStateUpdateConsumer.state.IsOver() {
+		t.Error("A new game should not be over")
+	}
+
+	// The shortest possible game, black wipes out white in nine moves
+	moves := []core.Coordinate{
+		{X: 2, Y: 4},
+		{X: 2, Y: 3},
+		{X: 1, Y: 2},
+		{X: 1, Y: 5},
+		{X: 1, Y: 4},
+		{X: 2, Y: 5},
+		{X: 4, Y: 2},
+		{X: 1, Y: 3},
+		{X: 1, Y: 6},
+	}
+	for _, move := range moves {
+		aggregator.SendEvent(core.NewMoveEvent(move))
+	}
+
+	gameState := testStateUpdateConsumer.state
+	if !gameState.IsOver() {
+		t.Fatal("The game should be over")
+	}
+
+	winner, decisive := gameState.Winner()
+	if !decisive || winner != core.BLACK {
+		t.Errorf("BLACK should have won, instead got %s", winner)
+	}
+	if gameState.DiscCount(core.WHITE) != 0 {
+		t.Errorf("WHITE should have no discs, instead has %d", gameState.DiscCount(core.WHITE))
+	}
+}
+
+func Test_CopiedState_isNotChangedByLaterMoves(t *testing.T) {
+	testStateUpdateConsumer := newTestStateUpdateConsumer()
+
+	aggregator := core.NewGameEventAggregator()
+	aggregator.Register(&testStateUpdateConsumer)
+
+	aggregator.SendEvent(core.NewInitializedEvent())
+	initial := testStateUpdateConsumer.state.Copy()
+
+	aggregator.SendEvent(core.NewMoveEvent(core.Coordinate{X: 2, Y: 4}))
+
+	if len(initial.Board) != 4 {
+		t.Errorf("The copied board should still have 4 discs, instead has %d", len(initial.Board))
+	}
+	if !initial.MoveOptions()[core.Coordinate{X: 2, Y: 4}] {
+		t.Error("The copied move options should not change")
+	}
+	shouldBeWhite(t, core.Coordinate{X: 3, Y: 4}, initial.Board)
+}
+
+func Test_InitializedFromEvent_startsFromThePosition(t *testing.T) {
+	position := replay(wholeGame[:20])[20]
+	testStateUpdateConsumer := newTestStateUpdateConsumer()
+
+	aggregator := core.NewGameEventAggregator()
+	aggregator.Register(&testStateUpdateConsumer)
+
+	initialized, err := core.NewInitializedFromEvent(position)
+	if err != nil {
+		t.Fatalf("Failed to start from %s: %s", position.Encode(), err.Error())
+	}
+
+	aggregator.SendEvent(initialized)
+	if !testStateUpdateConsumer.state.SamePosition(position) {
+		t.Fatalf("Expected %s, instead got %s", position.Encode(), testStateUpdateConsumer.state.Encode())
+	}
+
+	aggregator.SendEvent(core.NewMoveEvent(wholeGame[20]))
+	if expected := replay(wholeGame[:21])[21]; !testStateUpdateConsumer.state.SamePosition(expected) {
+		t.Errorf("Expected %s after %s, instead got %s", expected.Encode(), wholeGame[20], testStateUpdateConsumer.state.Encode())
+	}
+}
+
+func Test_InitializedFromEvent_refusesAPositionWithoutASideToMove(t *testing.T) {
+	if _, err := core.NewInitializedFromEvent(core.GameState{}); !errors.Is(err, core.ErrBadPosition) {
+		t.Errorf("Expected an empty state to be refused, instead got %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrBadMove = errors.New("not a move")
+
+// String writes the coordinate in Othello notation, the column as a letter
+// from a and the row as a number from 1, so {X: 2, Y: 3} is c4.
+func (coordinate Coordinate) String() string {
+	if !inBounds(coordinate) {
+		return fmt.Sprintf("(%d, %d)", coordinate.X, coordinate.Y)
+	}
+
+	return string(rune('a'+coordinate.X)) + string(rune('1'+coordinate.Y))
+}
+
+// ParseMove reads a square in either case, such as d3 or D3.
+func ParseMove(text string) (Coordinate, error) {
+	square := strings.ToLower(strings.TrimSpace(text))
+	if len(square) != 2 || square[0] < 'a' || square[0] > 'h' || square[1] < '1' || square[1] > '8' {
+		return Coordinate{}, fmt.Errorf("%w: %q", ErrBadMove, text)
+	}
+
+	return Coordinate{X: int(square[0] - 'a'), Y: int(square[1] - '1')}, nil
+}
+
+// FormatMoves writes a transcript of the moves, such as e3 f3 f4.
+func FormatMoves(moves []Coordinate) string {
+	squares := make([]string, len(moves))
+	for i, move := range moves {
+		squares[i] = move.String()
+	}
+
+	return strings.Join(squares, " ")
+}
+
+// ParseMoves reads a transcript, the moves either separated by spaces or run
+// together as in e3f3f4.
+func ParseMoves(text string) ([]Coordinate, error) {
+	squares := strings.Join(strings.Fields(text), "")
+	if len(squares)%2 != 0 {
+		return nil, fmt.Errorf("%w: %q has a square missing a row", ErrBadMove, text)
+	}
+
+	moves := []Coordinate{}
+	for i := 0; i < len(squares); i += 2 {
+		move, err := ParseMove(squares[i : i+2])
+		if err != nil {
+			return nil, err
+		}
+		moves = append(moves, move)
+	}
+
+	return moves, nil
+}
@@ -0,0 +1,66 @@
+package core_test
+
+import (
+	"errors"
+	"reversi/core"
+	"testing"
+)
+
+func Test_Notation_namesTheColumnThenTheRow(t *testing.T) {
+	for _, square := range []struct {
+		coordinate core.Coordinate
+		name       string
+	}{
+		{core.Coordinate{X: 0, Y: 0}, "a1"},
+		{core.Coordinate{X: 2, Y: 3}, "c4"},
+		{core.Coordinate{X: 7, Y: 0}, "h1"},
+		{core.Coordinate{X: 7, Y: 7}, "h8"},
+	} {
+		if name := square.coordinate.String(); name != square.name {
+			t.Errorf("Expected (%d, %d) to be %s, instead got %s", square.coordinate.X, square.coordinate.Y, square.name, name)
+		}
+	}
+}
+
+func Test_ParseMove_readsBackEverySquare(t *testing.T) {
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			coordinate := core.Coordinate{X: x, Y: y}
+
+			parsed, err := core.ParseMove(coordinate.String())
+			if err != nil || parsed != coordinate {
+				t.Errorf("Expected to read %s back as (%d, %d), instead got %+v (%v)", coordinate, x, y, parsed, err)
+			}
+		}
+	}
+
+	if parsed, err := core.ParseMove(" D3 "); err != nil || parsed != (core.Coordinate{X: 3, Y: 2}) {
+		t.Errorf("Expected D3 to be read in upper case, instead got %+v (%v)", parsed, err)
+	}
+}
+
+func Test_ParseMove_rejectsWhatIsNotASquare(t *testing.T) {
+	for _, text := range []string{"", "d", "d9", "i1", "a0", "d33", "33"} {
+		if _, err := core.ParseMove(text); !errors.Is(err, core.ErrBadMove) {
+			t.Errorf("Expected %q to be refused, instead got %v", text, err)
+		}
+	}
+}
+
+func Test_ParseMoves_readsBackATranscript(t *testing.T) {
+	transcript := core.FormatMoves(wholeGame)
+
+	moves, err := core.ParseMoves(transcript)
+	if err != nil || len(moves) != len(wholeGame) {
+		t.Fatalf("Expected %d moves from %s, instead got %d (%v)", len(wholeGame), transcript, len(moves), err)
+	}
+	for i, move := range moves {
+		if move != wholeGame[i] {
+			t.Fatalf("Expected move %d to be %s, instead got %s", i+1, wholeGame[i], move)
+		}
+	}
+
+	if runTogether, err := core.ParseMoves("e3f3 F4"); err != nil || core.FormatMoves(runTogether) != "e3 f3 f4" {
+		t.Errorf("Expected e3 f3 f4, instead got %v (%v)", runTogether, err)
+	}
+}
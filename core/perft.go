@@ -0,0 +1,44 @@
+package core
+
+// perft counts the positions depth plies on. A side that has to pass spends
+// a ply doing so, and a game that is over counts once however many plies
+// were left.
+func perft(gameState GameState, depth int, side Player) uint64 {
+	if depth == 0 || gameState.IsOver() {
+		return 1
+	}
+
+	// The other side had no move, the turn came straight back.
+	if gameState.PlayerTurn != side {
+		return perft(gameState, depth-1, side.Opposite())
+	}
+
+	var leaves uint64
+	for move := range gameState.MoveOptions() {
+		leaves += perft(applyMove(gameState.Copy(), side, move), depth-1, side.Opposite())
+	}
+
+	return leaves
+}
+
+// Perft counts the leaves of the game tree depth plies from the position,
+// passes included, to check the move generation against known counts.
+func Perft(gameState GameState, depth int) uint64 {
+	return perft(gameState, depth, gameState.PlayerTurn)
+}
+
+// Divide is Perft split by the first move, so a wrong count can be followed
+// down to the move that causes it.
+func Divide(gameState GameState, depth int) map[Coordinate]uint64 {
+	counts := make(map[Coordinate]uint64)
+	if depth == 0 || gameState.IsOver() {
+		return counts
+	}
+
+	side := gameState.PlayerTurn
+	for move := range gameState.MoveOptions() {
+		counts[move] = perft(applyMove(gameState.Copy(), side, move), depth-1, side.Opposite())
+	}
+
+	return counts
+}
@@ -0,0 +1,56 @@
+package core_test
+
+import (
+	"reversi/core"
+	"testing"
+)
+
+// startingCounts are the published perft counts of the starting position.
+var startingCounts = []uint64{1, 4, 12, 56, 244, 1396, 8200}
+
+func Test_Perft_ofTheStartingPosition_matchesTheReferenceCounts(t *testing.T) {
+	start := replay(nil)[0]
+
+	for depth, expected := range startingCounts {
+		if leaves := core.Perft(start, depth); leaves != expected {
+			t.Errorf("Expected %d leaves at depth %d, instead got %d", expected, depth, leaves)
+		}
+	}
+}
+
+func Test_Perft_countsPassesAsPlies(t *testing.T) {
+	// Counted with the engine's bitboards, both trees are full of passes and
+	// finished games.
+	for _, reference := range []struct {
+		played int
+		leaves uint64
+	}{
+		{51, 2400},
+		{53, 425},
+	} {
+		state := replay(wholeGame[:reference.played])[reference.played]
+		if leaves := core.Perft(state, 6); leaves != reference.leaves {
+			t.Errorf("After %d moves expected %d leaves, instead got %d", reference.played, reference.leaves, leaves)
+		}
+	}
+}
+
+func Test_Divide_addsUpToPerft(t *testing.T) {
+	state := replay(wholeGame[:20])[20]
+
+	counts := core.Divide(state, 3)
+	if len(counts) != len(state.MoveOptions()) {
+		t.Fatalf("Expected a count for each of the %d moves, instead got %d", len(state.MoveOptions()), len(counts))
+	}
+
+	var total uint64
+	for move, leaves := range counts {
+		if !state.MoveOptions()[move] {
+			t.Errorf("(%d, %d) is not a legal move", move.X, move.Y)
+		}
+		total += leaves
+	}
+	if total != core.Perft(state, 3) {
+		t.Errorf("Expected the moves to add up to %d leaves, instead got %d", core.Perft(state, 3), total)
+	}
+}
@@ -0,0 +1,159 @@
+package core
+
+// PositionMetrics describes a position from the point of view of one side.
+type PositionMetrics struct {
+	// Mobility counts the legal moves.
+	Mobility int
+	// PotentialMobility counts the empty squares next to an opponent disc,
+	// where moves may open up later.
+	PotentialMobility int
+	// FrontierDiscs counts the discs next to an empty square, they give the
+	// opponent something to flip.
+	FrontierDiscs int
+	// StableDiscs counts the discs that can never be flipped.
+	StableDiscs int
+	Corners     int
+	// XSquares are diagonally next to a corner and CSquares next to it on an
+	// edge, both tend to give the corner away while it is empty.
+	XSquares int
+	CSquares int
+}
+
+var (
+	corners  = []Coordinate{{X: 0, Y: 0}, {X: 7, Y: 0}, {X: 0, Y: 7}, {X: 7, Y: 7}}
+	xSquares = []Coordinate{{X: 1, Y: 1}, {X: 6, Y: 1}, {X: 1, Y: 6}, {X: 6, Y: 6}}
+	cSquares = []Coordinate{
+		{X: 1, Y: 0}, {X: 0, Y: 1}, {X: 6, Y: 0}, {X: 7, Y: 1},
+		{X: 0, Y: 6}, {X: 1, Y: 7}, {X: 7, Y: 6}, {X: 6, Y: 7},
+	}
+	// axes are the four lines through a square a disc can be flipped along.
+	axes = []Direction{{X: 1, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: -1}}
+)
+
+func countOwned(side Player, squares []Coordinate, board map[Coordinate]CellClaim) int {
+	count := 0
+	for _, square := range squares {
+		if ownClaim(side, square, board) {
+			count++
+		}
+	}
+
+	return count
+}
+
+func nextToEmpty(coordinate Coordinate, board map[Coordinate]CellClaim) bool {
+	for _, neighbour := range getNeighborhood(coordinate) {
+		if inBounds(neighbour) && !isOccupied(neighbour, board) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func nextToOpponent(side Player, coordinate Coordinate, board map[Coordinate]CellClaim) bool {
+	for _, neighbour := range getNeighborhood(coordinate) {
+		if oppositeClaim(side, neighbour, board) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// lineIsFull is true when no square along the axis through the coordinate
+// is empty, nothing can be flipped along a full line.
+func lineIsFull(coordinate Coordinate, axis Direction, board map[Coordinate]CellClaim) bool {
+	for _, direction := range []Direction{axis, {X: -axis.X, Y: -axis.Y}} {
+		for square := step(coordinate, direction); inBounds(square); square = step(square, direction) {
+			if !isOccupied(square, board) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// anchored is true when the disc cannot be flipped along the axis: the line
+// is full or on one side it is held by the board's edge or a stable disc of
+// the same colour.
+func anchored(coordinate Coordinate, axis Direction, side Player, stable map[Coordinate]bool, board map[Coordinate]CellClaim) bool {
+	for _, direction := range []Direction{axis, {X: -axis.X, Y: -axis.Y}} {
+		neighbour := step(coordinate, direction)
+		if !inBounds(neighbour) || (stable[neighbour] && ownClaim(side, neighbour, board)) {
+			return true
+		}
+	}
+
+	return lineIsFull(coordinate, axis, board)
+}
+
+// stableDiscs grows the set of stable discs from the edges and full lines
+// until no more discs can be added. Discs it misses may still be stable,
+// but every disc it finds is.
+func stableDiscs(board map[Coordinate]CellClaim) map[Coordinate]bool {
+	stable := make(map[Coordinate]bool)
+
+	for changed := true; changed; {
+		changed = false
+		for coordinate, claim := range board {
+			if stable[coordinate] || claim == nil {
+				continue
+			}
+
+			side := BLACK
+			if claim.OwnedBy(WHITE) {
+				side = WHITE
+			}
+
+			held := true
+			for _, axis := range axes {
+				if !anchored(coordinate, axis, side, stable, board) {
+					held = false
+					break
+				}
+			}
+
+			if held {
+				stable[coordinate] = true
+				changed = true
+			}
+		}
+	}
+
+	return stable
+}
+
+// Metrics works out the PositionMetrics of the side, whichever side is to
+// move.
+func (gameState GameState) Metrics(side Player) PositionMetrics {
+	board := gameState.Board
+	metrics := PositionMetrics{
+		Mobility: len(possibleMovesFor(gameState.Edge, board, side).moves),
+		Corners:  countOwned(side, corners, board),
+		XSquares: countOwned(side, xSquares, board),
+		CSquares: countOwned(side, cSquares, board),
+	}
+
+	for square := range gameState.Edge {
+		if inBounds(square) && !isOccupied(square, board) && nextToOpponent(side, square, board) {
+			metrics.PotentialMobility++
+		}
+	}
+
+	stable := stableDiscs(board)
+	for coordinate := range board {
+		if !ownClaim(side, coordinate, board) {
+			continue
+		}
+		if nextToEmpty(coordinate, board) {
+			metrics.FrontierDiscs++
+		}
+		if stable[coordinate] {
+			metrics.StableDiscs++
+		}
+	}
+
+	return metrics
+}
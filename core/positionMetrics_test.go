@@ -0,0 +1,65 @@
+package core_test
+
+import (
+	"reversi/core"
+	"testing"
+)
+
+func Test_Metrics_ofTheStartingPosition(t *testing.T) {
+	start := replay(nil)[0]
+
+	expected := core.PositionMetrics{Mobility: 4, PotentialMobility: 10, FrontierDiscs: 2}
+	for _, side := range []core.Player{core.BLACK, core.WHITE} {
+		if metrics := start.Metrics(side); metrics != expected {
+			t.Errorf("Expected %+v for %s, instead got %+v", expected, side, metrics)
+		}
+	}
+}
+
+func Test_Metrics_mobilityMatchesTheMoveOptions(t *testing.T) {
+	for i, state := range replay(wholeGame) {
+		if state.IsOver() {
+			continue
+		}
+
+		if state.Metrics(state.PlayerTurn).Mobility != len(state.MoveOptions()) {
+			t.Errorf("After %d moves expected a mobility of %d, instead got %d", i, len(state.MoveOptions()), state.Metrics(state.PlayerTurn).Mobility)
+		}
+	}
+}
+
+func Test_StableDiscs_neverBecomeFewer(t *testing.T) {
+	states := replay(wholeGame)
+
+	for _, side := range []core.Player{core.BLACK, core.WHITE} {
+		previous := 0
+		for i, state := range states {
+			metrics := state.Metrics(side)
+			if metrics.StableDiscs < previous {
+				t.Fatalf("%s had %d stable discs after %d moves and %d after the next", side, previous, i-1, metrics.StableDiscs)
+			}
+			if metrics.StableDiscs > state.DiscCount(side) || metrics.StableDiscs < metrics.Corners {
+				t.Fatalf("After %d moves %s has %d discs and %d corners but %d stable discs", i, side, state.DiscCount(side), metrics.Corners, metrics.StableDiscs)
+			}
+			previous = metrics.StableDiscs
+		}
+	}
+
+	final := states[len(states)-1]
+	for _, side := range []core.Player{core.BLACK, core.WHITE} {
+		metrics := final.Metrics(side)
+		if metrics.StableDiscs != final.DiscCount(side) || metrics.FrontierDiscs != 0 || metrics.PotentialMobility != 0 {
+			t.Errorf("On a full board every disc of %s is stable and none is on the frontier, got %+v", side, metrics)
+		}
+	}
+}
+
+func Test_CornerSquares_areCountedForTheirOwner(t *testing.T) {
+	final := replay(wholeGame)[len(wholeGame)]
+	black := final.Metrics(core.BLACK)
+	white := final.Metrics(core.WHITE)
+
+	if black.Corners+white.Corners != 4 || black.XSquares+white.XSquares != 4 || black.CSquares+white.CSquares != 8 {
+		t.Errorf("Every corner, X and C square is taken on a full board, got %+v and %+v", black, white)
+	}
+}
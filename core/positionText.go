@@ -0,0 +1,114 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	blackSquare = 'X'
+	whiteSquare = 'O'
+	emptySquare = '-'
+)
+
+var ErrBadPosition = errors.New("not a position")
+
+// squareOwner reads the usual ways of writing a square, X, B, * or @ for
+// black, O or W for white and -, . or _ for an empty square.
+func squareOwner(square byte) (Player, bool, error) {
+	switch square {
+	case 'X', 'x', 'B', 'b', '*', '@':
+		return BLACK, true, nil
+	case 'O', 'o', 'W', 'w':
+		return WHITE, true, nil
+	case '-', '.', '_':
+		return "", false, nil
+	}
+
+	return "", false, fmt.Errorf("%w: %q is not a square", ErrBadPosition, square)
+}
+
+// Encode writes the position the way Othello programs exchange boards: the
+// 64 squares a1 to h1, a2 to h2 and so on to h8, X for black, O for white
+// and - for empty, then a space and the side to move.
+func (gameState GameState) Encode() string {
+	var text strings.Builder
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			claim, found := gameState.Board[Coordinate{X: x, Y: y}]
+			switch {
+			case found && claim.OwnedBy(BLACK):
+				text.WriteByte(blackSquare)
+			case found && claim.OwnedBy(WHITE):
+				text.WriteByte(whiteSquare)
+			default:
+				text.WriteByte(emptySquare)
+			}
+		}
+	}
+
+	side := byte(blackSquare)
+	if gameState.PlayerTurn == WHITE {
+		side = whiteSquare
+	}
+	text.WriteByte(' ')
+	text.WriteByte(side)
+
+	return text.String()
+}
+
+// ParsePosition reads a position written by Encode. Spaces between the
+// squares are ignored and anything after a ; is taken as a comment, as in
+// obf files. When the side to move has to pass the other side is to move, as
+// it would be in a game.
+func ParsePosition(text string) (GameState, error) {
+	if comment := strings.IndexByte(text, ';'); comment >= 0 {
+		text = text[:comment]
+	}
+	squares := strings.Join(strings.Fields(text), "")
+	if len(squares) != 65 {
+		return GameState{}, fmt.Errorf("%w: expected 64 squares and the side to move, got %d characters", ErrBadPosition, len(squares))
+	}
+
+	board := make(map[Coordinate]CellClaim)
+	for index := 0; index < 64; index++ {
+		side, owned, err := squareOwner(squares[index])
+		if err != nil {
+			return GameState{}, err
+		}
+		if !owned {
+			continue
+		}
+
+		coordinate := Coordinate{X: index % 8, Y: index / 8}
+		if side == BLACK {
+			board[coordinate] = ownedByBlack{}
+		} else {
+			board[coordinate] = ownedByWhite{}
+		}
+	}
+
+	turn, owned, err := squareOwner(squares[64])
+	if err != nil || !owned {
+		return GameState{}, fmt.Errorf("%w: %q is not a side to move", ErrBadPosition, squares[64])
+	}
+
+	used := collectUsed(board)
+	edge := collectEdge(board, used)
+	possibleMoves := possibleMovesFor(edge, board, turn)
+	if len(possibleMoves.moves) == 0 {
+		if passed := possibleMovesFor(edge, board, turn.Opposite()); len(passed.moves) > 0 {
+			possibleMoves, turn = passed, turn.Opposite()
+		}
+	}
+
+	return GameState{
+		Board:         board,
+		PlayerTurn:    turn,
+		Used:          used,
+		Edge:          edge,
+		PossibleMoves: possibleMoves,
+		Hash:          ComputeHash(board, turn),
+	}, nil
+}
@@ -0,0 +1,68 @@
+package core_test
+
+import (
+	"errors"
+	"reversi/core"
+	"strings"
+	"testing"
+)
+
+const startingPosition = "---------------------------XO------OX--------------------------- X"
+
+func Test_Encode_ofTheStartingPosition(t *testing.T) {
+	if encoded := replay(nil)[0].Encode(); encoded != startingPosition {
+		t.Errorf("Expected %s, instead got %s", startingPosition, encoded)
+	}
+}
+
+func Test_ParsePosition_readsBackEveryPositionOfAGame(t *testing.T) {
+	for i, state := range replay(wholeGame) {
+		parsed, err := core.ParsePosition(state.Encode())
+		if err != nil {
+			t.Fatalf("After %d moves failed to read %s: %s", i, state.Encode(), err.Error())
+		}
+
+		if !parsed.SamePosition(state) || parsed.IsOver() != state.IsOver() {
+			t.Fatalf("After %d moves expected %s, instead read %s", i, state.Encode(), parsed.Encode())
+		}
+		if core.Perft(parsed, 2) != core.Perft(state, 2) {
+			t.Fatalf("After %d moves the position read plays on differently", i)
+		}
+	}
+}
+
+func Test_ParsePosition_acceptsOtherSquareCharactersAndComments(t *testing.T) {
+	text := strings.Replace(strings.Replace(startingPosition, "-", ".", -1), "XO", "*o", 1)
+	text = text[:32] + " " + text[32:] + "; the start"
+
+	parsed, err := core.ParsePosition(text)
+	if err != nil || parsed.Encode() != startingPosition {
+		t.Errorf("Expected the starting position, instead got %s (%v)", parsed.Encode(), err)
+	}
+}
+
+func Test_ParsePosition_passesForASideWithoutMoves(t *testing.T) {
+	// White cannot take the cornered black disc, black can take the white one.
+	parsed, err := core.ParsePosition("XO" + strings.Repeat("-", 62) + " O")
+	if err != nil {
+		t.Fatalf("Expected a position, instead got %s", err.Error())
+	}
+
+	if parsed.PlayerTurn != core.BLACK || len(parsed.MoveOptions()) != 1 || !parsed.MoveOptions()[core.Coordinate{X: 2, Y: 0}] {
+		t.Errorf("Expected black to move at (2, 0), instead %s to move with %v", parsed.PlayerTurn, parsed.MoveOptions())
+	}
+}
+
+func Test_ParsePosition_rejectsWhatIsNotAPosition(t *testing.T) {
+	for _, text := range []string{
+		"",
+		startingPosition[:64],
+		startingPosition[:64] + " -",
+		strings.Replace(startingPosition, "X", "Z", 1),
+		startingPosition + "X",
+	} {
+		if _, err := core.ParsePosition(text); !errors.Is(err, core.ErrBadPosition) {
+			t.Errorf("Expected %q to be refused, instead got %v", text, err)
+		}
+	}
+}
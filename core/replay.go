@@ -0,0 +1,46 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrIllegalMove = errors.New("the game contains an illegal move")
+
+// StartingPosition is the position every game on the server starts from.
+func StartingPosition() GameState {
+	return getInitialGameState()
+}
+
+type stateRecorder struct {
+	states []GameState
+}
+
+func (recorder *stateRecorder) StateUpdated(gameState GameState) {
+	recorder.states = append(recorder.states, gameState.Copy())
+}
+
+// Replay plays the moves from start through the aggregator and returns the
+// position before the first move and after each one. The turn passes on its
+// own, so a side without a move is not in the moves. A start without a side
+// to move is refused with ErrBadPosition.
+func Replay(start GameState, moves []Coordinate) ([]GameState, error) {
+	recorder := &stateRecorder{}
+	aggregator := NewGameEventAggregator()
+	aggregator.Register(recorder)
+
+	initialized, err := NewInitializedFromEvent(start)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregator.SendEvent(initialized)
+	for i, move := range moves {
+		if !recorder.states[i].MoveOptions()[move] {
+			return nil, fmt.Errorf("%w: move %d %s", ErrIllegalMove, i+1, move)
+		}
+		aggregator.SendEvent(NewMoveEvent(move))
+	}
+
+	return recorder.states, nil
+}
@@ -0,0 +1,32 @@
+package core_test
+
+import (
+	"errors"
+	"reversi/core"
+	"testing"
+)
+
+func Test_Replay_returnsThePositionBeforeAndAfterEveryMove(t *testing.T) {
+	states, err := core.Replay(core.StartingPosition(), wholeGame)
+	if err != nil || len(states) != len(wholeGame)+1 {
+		t.Fatalf("Expected %d positions, instead got %d (%v)", len(wholeGame)+1, len(states), err)
+	}
+
+	if !states[len(states)-1].IsOver() {
+		t.Errorf("Expected the game to be over, instead got %s", states[len(states)-1].Encode())
+	}
+
+	// Playing on from a position carries on the same game.
+	later, err := core.Replay(states[30], wholeGame[30:])
+	if err != nil || !later[len(later)-1].SamePosition(states[len(states)-1]) {
+		t.Errorf("Expected the same final position from move 30, instead got %v", err)
+	}
+}
+
+func Test_Replay_refusesAnIllegalMove(t *testing.T) {
+	moves := []core.Coordinate{wholeGame[0], wholeGame[0]}
+
+	if _, err := core.Replay(core.StartingPosition(), moves); !errors.Is(err, core.ErrIllegalMove) {
+		t.Errorf("Expected %s played twice to be refused, instead got %v", wholeGame[0], err)
+	}
+}
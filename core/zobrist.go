@@ -0,0 +1,87 @@
+package core
+
+// Zobrist keys identify a position with a single number: the keys of every
+// disc on the board are xored together, along with whiteToMoveKey when it is
+// WHITE's turn. Playing a move only changes the keys of the cells it touches,
+// so the hash is kept up to date as moves are applied.
+var (
+	zobristKeys    [2][64]uint64
+	whiteToMoveKey uint64
+)
+
+// splitMix64 makes the keys the same on every run, hashes can be stored and
+// compared between processes.
+func splitMix64(state *uint64) uint64 {
+	*state += 0x9e3779b97f4a7c15
+	z := *state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+func init() {
+	state := uint64(0x5eed)
+	for side := range zobristKeys {
+		for cell := range zobristKeys[side] {
+			zobristKeys[side][cell] = splitMix64(&state)
+		}
+	}
+	whiteToMoveKey = splitMix64(&state)
+}
+
+func sideIndex(side Player) int {
+	if side == WHITE {
+		return 1
+	}
+
+	return 0
+}
+
+// ZobristKey is the key of a disc of the given side on the cell.
+func ZobristKey(coordinate Coordinate, side Player) uint64 {
+	return zobristKeys[sideIndex(side)][coordinate.Y*8+coordinate.X]
+}
+
+// TurnKey is xored into the hash of every position with WHITE to move.
+func TurnKey(side Player) uint64 {
+	if side == WHITE {
+		return whiteToMoveKey
+	}
+
+	return 0
+}
+
+// ComputeHash hashes a board from scratch.
+func ComputeHash(board map[Coordinate]CellClaim, turn Player) uint64 {
+	hash := TurnKey(turn)
+	for coordinate, owner := range board {
+		if owner.OwnedBy(BLACK) {
+			hash ^= ZobristKey(coordinate, BLACK)
+		} else if owner.OwnedBy(WHITE) {
+			hash ^= ZobristKey(coordinate, WHITE)
+		}
+	}
+
+	return hash
+}
+
+// SamePosition reports whether both states have the same discs and the same
+// side to move, however they were reached.
+func (gameState GameState) SamePosition(other GameState) bool {
+	if gameState.Hash != other.Hash || gameState.PlayerTurn != other.PlayerTurn {
+		return false
+	}
+
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			coordinate := Coordinate{X: x, Y: y}
+			for _, side := range []Player{BLACK, WHITE} {
+				if matchingSide(side, coordinate, gameState.Board) != matchingSide(side, coordinate, other.Board) {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,61 @@
+package core_test
+
+import (
+	"reversi/core"
+	"testing"
+)
+
+// replay returns the state after initialization and after every move.
+func replay(moves []core.Coordinate) []core.GameState {
+	states, err := core.Replay(core.StartingPosition(), moves)
+	if err != nil {
+		panic(err)
+	}
+
+	return states
+}
+
+var wholeGame = []core.Coordinate{
+	{X: 2, Y: 4}, {X: 2, Y: 5}, {X: 2, Y: 6}, {X: 1, Y: 4}, {X: 0, Y: 4}, {X: 4, Y: 5},
+	{X: 5, Y: 2}, {X: 4, Y: 2}, {X: 3, Y: 2}, {X: 2, Y: 1}, {X: 3, Y: 1}, {X: 4, Y: 1},
+	{X: 3, Y: 0}, {X: 1, Y: 5}, {X: 4, Y: 6}, {X: 2, Y: 7}, {X: 3, Y: 6}, {X: 1, Y: 3},
+	{X: 0, Y: 5}, {X: 3, Y: 5}, {X: 0, Y: 2}, {X: 1, Y: 2}, {X: 2, Y: 3}, {X: 2, Y: 2},
+	{X: 3, Y: 7}, {X: 0, Y: 3}, {X: 2, Y: 0}, {X: 6, Y: 2}, {X: 7, Y: 2}, {X: 5, Y: 3},
+	{X: 6, Y: 4}, {X: 5, Y: 4}, {X: 4, Y: 7}, {X: 7, Y: 4}, {X: 7, Y: 5}, {X: 5, Y: 7},
+	{X: 7, Y: 3}, {X: 6, Y: 3}, {X: 5, Y: 6}, {X: 5, Y: 5}, {X: 6, Y: 5}, {X: 5, Y: 0},
+	{X: 5, Y: 1}, {X: 4, Y: 0}, {X: 6, Y: 0}, {X: 1, Y: 7}, {X: 1, Y: 1}, {X: 1, Y: 0},
+	{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 6}, {X: 0, Y: 7}, {X: 0, Y: 6}, {X: 7, Y: 6},
+	{X: 7, Y: 7}, {X: 6, Y: 6}, {X: 6, Y: 7}, {X: 7, Y: 1}, {X: 6, Y: 1}, {X: 7, Y: 0},
+}
+
+func Test_Hash_isKeptUpToDateAsMovesAreApplied(t *testing.T) {
+	for i, state := range replay(wholeGame) {
+		expected := core.ComputeHash(state.Board, state.PlayerTurn)
+		if state.Hash != expected {
+			t.Errorf("After %d moves the hash is %x, hashing the board gives %x", i, state.Hash, expected)
+		}
+	}
+}
+
+func Test_Hash_includesTheSideToMove(t *testing.T) {
+	state := replay(nil)[0]
+
+	if core.ComputeHash(state.Board, core.WHITE) == state.Hash {
+		t.Error("The same board with the other side to move should hash differently")
+	}
+}
+
+func Test_TransposedMoveOrders_reachTheSamePosition(t *testing.T) {
+	first := replay([]core.Coordinate{{X: 4, Y: 2}, {X: 3, Y: 2}, {X: 2, Y: 1}, {X: 3, Y: 1}, {X: 2, Y: 5}, {X: 5, Y: 1}})
+	second := replay([]core.Coordinate{{X: 4, Y: 2}, {X: 3, Y: 2}, {X: 2, Y: 1}, {X: 5, Y: 1}, {X: 2, Y: 5}, {X: 3, Y: 1}})
+
+	a := first[len(first)-1]
+	b := second[len(second)-1]
+
+	if a.Hash != b.Hash || !a.SamePosition(b) {
+		t.Error("Both move orders should reach the same position")
+	}
+	if a.SamePosition(first[len(first)-2]) {
+		t.Error("Positions with different boards should not be the same")
+	}
+}
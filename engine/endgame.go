@@ -0,0 +1,315 @@
+package engine
+
+import (
+	"errors"
+	"math/bits"
+	"reversi/core"
+	"sort"
+	"time"
+)
+
+type SolveMode string
+
+const (
+	EXACT         SolveMode = "EXACT"
+	WIN_LOSS_DRAW SolveMode = "WIN_LOSS_DRAW"
+)
+
+const (
+	// Below this many empties ordering costs more than it saves.
+	orderingEmpties      = 7
+	maximumMargin        = 64
+	solverTableMegabytes = 4
+	// MaximumEmpties is the most empties solved without a time limit, larger
+	// endgames would run for hours.
+	MaximumEmpties = 20
+)
+
+var (
+	ErrSolveTimeout   = errors.New("the endgame could not be solved within the time limit")
+	ErrTooManyEmpties = errors.New("the position has too many empties to solve without a time limit")
+)
+
+type EndgameLimits struct {
+	Mode SolveMode
+	Time time.Duration
+}
+
+// Solution is the outcome of perfect play from a position. Margin is the
+// final disc difference for the side to move, in WIN_LOSS_DRAW mode only its
+// sign is known and it is 1, 0 or -1, and a winning Line stops at the move
+// that proves the win.
+type Solution struct {
+	Move    core.Coordinate
+	Margin  int
+	Mode    SolveMode
+	Line    []core.Coordinate
+	Nodes   uint64
+	Elapsed time.Duration
+}
+
+type solver struct {
+	table    *TranspositionTable
+	deadline time.Time
+	aborted  bool
+	nodes    uint64
+}
+
+var quadrants = [4]uint64{
+	0x000000000f0f0f0f,
+	0x00000000f0f0f0f0,
+	0x0f0f0f0f00000000,
+	0xf0f0f0f000000000,
+}
+
+func quadrantOf(index int) uint64 {
+	for _, quadrant := range quadrants {
+		if quadrant&(uint64(1)<<uint(index)) != 0 {
+			return quadrant
+		}
+	}
+
+	return 0
+}
+
+// orderMoves tries moves in regions with an odd number of empties first, the
+// player filling such a region usually gets the last move in it. With many
+// empties the move the table remembers comes first, then moves that leave
+// the opponent few replies.
+func orderMoves(position Position, moves uint64, tableMove int) []int {
+	ordered := moveList(moves)
+	empty := ^(position.Player | position.Opponent)
+
+	oddParity := func(index int) bool {
+		return bits.OnesCount64(empty&quadrantOf(index))%2 == 1
+	}
+
+	if position.Empties() < orderingEmpties {
+		sort.SliceStable(ordered, func(i, j int) bool {
+			return oddParity(ordered[i]) && !oddParity(ordered[j])
+		})
+		return ordered
+	}
+
+	var replies [64]int
+	for _, move := range ordered {
+		replies[move] = bits.OnesCount64(position.Play(move).Moves())
+	}
+
+	if tableMove != passMove {
+		replies[tableMove] = -1
+	}
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if replies[ordered[i]] != replies[ordered[j]] {
+			return replies[ordered[i]] < replies[ordered[j]]
+		}
+		return oddParity(ordered[i]) && !oddParity(ordered[j])
+	})
+
+	return ordered
+}
+
+func (solver *solver) timeIsUp() bool {
+	if !solver.aborted && !solver.deadline.IsZero() && solver.nodes%nodesPerPoll == 0 {
+		solver.aborted = time.Now().After(solver.deadline)
+	}
+
+	return solver.aborted
+}
+
+func (solver *solver) solve(position Position, alpha int, beta int, ply int, line *[]int) int {
+	solver.nodes++
+	if solver.timeIsUp() {
+		return 0
+	}
+
+	moves := position.Moves()
+	if moves == 0 {
+		if position.OpponentMoves() == 0 {
+			*line = nil
+			return position.DiscDifference()
+		}
+
+		var child []int
+		score := -solver.solve(position.Pass(), -beta, -alpha, ply+1, &child)
+		*line = append([]int{passMove}, child...)
+		return score
+	}
+
+	// Close to the end the table costs more than searching again.
+	remember := position.Empties() >= orderingEmpties
+	originalAlpha, originalBeta := alpha, beta
+	tableMove := passMove
+	if remember {
+		entry, found := solver.table.Probe(position.Hash)
+		if found && ply == 0 {
+			tableMove = entry.Move
+		} else if found {
+			tableMove = entry.Move
+			switch entry.Bound {
+			case EXACT_SCORE:
+				*line = nil
+				return entry.Score
+			case LOWER_BOUND:
+				if entry.Score > alpha {
+					alpha = entry.Score
+				}
+			case UPPER_BOUND:
+				if entry.Score < beta {
+					beta = entry.Score
+				}
+			}
+			if alpha >= beta {
+				*line = nil
+				return entry.Score
+			}
+		}
+	}
+
+	best := -maximumMargin - 1
+	bestMove := passMove
+	for _, move := range orderMoves(position, moves, tableMove) {
+		var child []int
+		score := -solver.solve(position.Play(move), -beta, -alpha, ply+1, &child)
+		if solver.aborted {
+			return 0
+		}
+
+		if score > best {
+			best = score
+			bestMove = move
+			if score > alpha {
+				alpha = score
+				*line = append([]int{move}, child...)
+			}
+		}
+
+		if alpha >= beta {
+			break
+		}
+	}
+
+	if remember {
+		solver.table.Store(position.Hash, TableEntry{
+			Move:  bestMove,
+			Score: best,
+			Depth: position.Empties(),
+			Bound: boundOf(best, originalAlpha, originalBeta),
+		})
+	}
+
+	return best
+}
+
+// completeLine plays on where the line was cut short by a score the table
+// remembered, until the game is over.
+func (solver *solver) completeLine(position Position, line []int) []int {
+	for _, move := range line {
+		if move == passMove {
+			position = position.Pass()
+		} else {
+			position = position.Play(move)
+		}
+	}
+
+	for !position.IsOver() && !solver.aborted {
+		if position.Moves() == 0 {
+			line = append(line, passMove)
+			position = position.Pass()
+			continue
+		}
+
+		var rest []int
+		solver.solve(position, -maximumMargin-1, maximumMargin+1, 0, &rest)
+		for _, move := range rest {
+			line = append(line, move)
+			if move == passMove {
+				position = position.Pass()
+			} else {
+				position = position.Play(move)
+			}
+		}
+	}
+
+	return line
+}
+
+func sign(value int) int {
+	if value > 0 {
+		return 1
+	}
+	if value < 0 {
+		return -1
+	}
+
+	return 0
+}
+
+func solvePosition(position Position, limits EndgameLimits) (Solution, error) {
+	if position.Moves() == 0 {
+		return Solution{}, ErrNoMoves
+	}
+	if limits.Time <= 0 && position.Empties() > MaximumEmpties {
+		return Solution{}, ErrTooManyEmpties
+	}
+
+	mode := limits.Mode
+	if mode == "" {
+		mode = EXACT
+	}
+
+	started := time.Now()
+	solver := &solver{table: NewTranspositionTable(solverTableMegabytes)}
+	if limits.Time > 0 {
+		solver.deadline = started.Add(limits.Time)
+	}
+
+	alpha, beta := -maximumMargin-1, maximumMargin+1
+	fullWindow := mode == EXACT
+	if !fullWindow {
+		alpha, beta = -1, 1
+	}
+
+	var line []int
+	score := solver.solve(position, alpha, beta, 0, &line)
+	if solver.aborted {
+		return Solution{}, ErrSolveTimeout
+	}
+
+	// When every move loses the narrow window fails low without a line, the
+	// full window finds the move that loses least and how the game goes.
+	if len(line) == 0 {
+		score = solver.solve(position, -maximumMargin-1, maximumMargin+1, 0, &line)
+		if solver.aborted {
+			return Solution{}, ErrSolveTimeout
+		}
+		fullWindow = true
+	}
+
+	if fullWindow {
+		line = solver.completeLine(position, line)
+		if solver.aborted {
+			return Solution{}, ErrSolveTimeout
+		}
+	}
+
+	margin := score
+	if mode == WIN_LOSS_DRAW {
+		margin = sign(score)
+	}
+
+	return Solution{
+		Move:    coordinateOf(line[0]),
+		Margin:  margin,
+		Mode:    mode,
+		Line:    toCoordinates(line),
+		Nodes:   solver.nodes,
+		Elapsed: time.Since(started),
+	}, nil
+}
+
+// Solve plays the position out perfectly for both sides.
+func Solve(gameState core.GameState, limits EndgameLimits) (Solution, error) {
+	return solvePosition(NewPosition(gameState), limits)
+}
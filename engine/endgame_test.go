@@ -0,0 +1,101 @@
+package engine_test
+
+import (
+	"reversi/core"
+	"reversi/engine"
+	"testing"
+	"time"
+)
+
+func Test_Solve_withManyEmpties_needsATimeLimit(t *testing.T) {
+	_, err := engine.Solve(stateAfter(wipeout[:8]), engine.EndgameLimits{})
+
+	if err != engine.ErrTooManyEmpties {
+		t.Errorf("Expected ErrTooManyEmpties, instead got %v", err)
+	}
+}
+
+func Test_Solve_agreesWithAFullDepthSearch(t *testing.T) {
+	state := stateAfter(wholeGame[:50])
+
+	solution, err := engine.Solve(state, engine.EndgameLimits{Mode: engine.EXACT})
+	if err != nil {
+		t.Fatalf("Expected a solution, instead got %s", err.Error())
+	}
+
+	result, _ := engine.BestMove(state, engine.Limits{Depth: 64})
+	margin, exact := engine.FinalMargin(result.Score)
+	if !exact && result.Score != 0 {
+		t.Fatalf("A full depth search should be exact, instead scored %d", result.Score)
+	}
+	if margin != solution.Margin {
+		t.Errorf("Expected the solver to agree with the search on %d, instead got %d", margin, solution.Margin)
+	}
+
+	states := replay(append(append([]core.Coordinate{}, wholeGame[:50]...), solution.Line...))
+	final := states[len(states)-1]
+	if !final.IsOver() {
+		t.Fatal("The solution line should finish the game")
+	}
+
+	difference := final.DiscCount(state.PlayerTurn) - final.DiscCount(state.PlayerTurn.Opposite())
+	if difference != solution.Margin {
+		t.Errorf("Playing out the solution line should end with a margin of %d, instead got %d", solution.Margin, difference)
+	}
+}
+
+func Test_Solve_winLossDraw_reportsTheSignOfTheMargin(t *testing.T) {
+	for played := 44; played < 52; played++ {
+		state := stateAfter(wholeGame[:played])
+
+		exact, _ := engine.Solve(state, engine.EndgameLimits{Mode: engine.EXACT})
+		outcome, err := engine.Solve(state, engine.EndgameLimits{Mode: engine.WIN_LOSS_DRAW})
+		if err != nil {
+			t.Fatalf("Expected a solution, instead got %s", err.Error())
+		}
+
+		expected := 0
+		if exact.Margin > 0 {
+			expected = 1
+		} else if exact.Margin < 0 {
+			expected = -1
+		}
+
+		if outcome.Margin != expected {
+			t.Errorf("After %d moves expected an outcome of %d for a margin of %d, instead got %d", played, expected, exact.Margin, outcome.Margin)
+		}
+		if expected > 0 && outcome.Nodes > exact.Nodes {
+			t.Errorf("After %d moves the win, loss or draw search should be cheaper, it took %d nodes against %d", played, outcome.Nodes, exact.Nodes)
+		}
+	}
+}
+
+func Test_Solve_winLossDraw_whenEveryMoveLoses_playsTheGameOut(t *testing.T) {
+	state := stateAfter(wholeGame[:46])
+
+	exact, _ := engine.Solve(state, engine.EndgameLimits{Mode: engine.EXACT})
+	outcome, err := engine.Solve(state, engine.EndgameLimits{Mode: engine.WIN_LOSS_DRAW})
+	if err != nil {
+		t.Fatalf("Expected a solution, instead got %s", err.Error())
+	}
+
+	if outcome.Margin != -1 {
+		t.Fatalf("Expected a loss, instead got %d", outcome.Margin)
+	}
+	if outcome.Move != exact.Move {
+		t.Errorf("Expected the move that loses least (%d, %d), instead got (%d, %d)", exact.Move.X, exact.Move.Y, outcome.Move.X, outcome.Move.Y)
+	}
+
+	states := replay(append(append([]core.Coordinate{}, wholeGame[:46]...), outcome.Line...))
+	if !states[len(states)-1].IsOver() {
+		t.Error("The solution line of a lost position should finish the game")
+	}
+}
+
+func Test_Solve_givesUpAtTheTimeLimit(t *testing.T) {
+	_, err := engine.Solve(stateAfter(wholeGame[:10]), engine.EndgameLimits{Time: 50 * time.Millisecond})
+
+	if err != engine.ErrSolveTimeout {
+		t.Errorf("Expected ErrSolveTimeout, instead got %v", err)
+	}
+}
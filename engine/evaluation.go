@@ -0,0 +1,87 @@
+package engine
+
+import "math/bits"
+
+const (
+	discValue     = 100
+	winningScore  = 1000000
+	mobilityValue = 15
+)
+
+var squareWeights = [64]int{
+	100, -20, 10, 5, 5, 10, -20, 100,
+	-20, -50, -2, -2, -2, -2, -50, -20,
+	10, -2, -1, -1, -1, -1, -2, 10,
+	5, -2, -1, -1, -1, -1, -2, 5,
+	5, -2, -1, -1, -1, -1, -2, 5,
+	10, -2, -1, -1, -1, -1, -2, 10,
+	-20, -50, -2, -2, -2, -2, -50, -20,
+	100, -20, 10, 5, 5, 10, -20, 100,
+}
+
+// Evaluator scores a position that is not over from the point of view of
+// the side to move. Larger is better, discValue is worth about one disc.
+type Evaluator interface {
+	Evaluate(position Position) int
+}
+
+type weightedSquareEvaluator struct{}
+
+func weightOf(discs uint64) int {
+	total := 0
+	for discs != 0 {
+		index := bits.TrailingZeros64(discs)
+		total += squareWeights[index]
+		discs &= discs - 1
+	}
+
+	return total
+}
+
+func (evaluator weightedSquareEvaluator) Evaluate(position Position) int {
+	squares := weightOf(position.Player) - weightOf(position.Opponent)
+	mobility := bits.OnesCount64(position.Moves()) - bits.OnesCount64(position.OpponentMoves())
+
+	return squares*discValue/10 + mobility*mobilityValue
+}
+
+func NewWeightedSquareEvaluator() Evaluator {
+	return weightedSquareEvaluator{}
+}
+
+// terminalScore ranks every win above every heuristic score, and bigger wins
+// above smaller ones.
+func terminalScore(difference int) int {
+	if difference > 0 {
+		return winningScore + difference*discValue
+	}
+	if difference < 0 {
+		return -winningScore + difference*discValue
+	}
+
+	return 0
+}
+
+// FinalMargin reports the exact disc margin a score guarantees, which is only
+// known when the search saw the end of the game.
+func FinalMargin(score int) (int, bool) {
+	if score >= winningScore {
+		return (score - winningScore) / discValue, true
+	}
+	if score <= -winningScore {
+		return (score + winningScore) / discValue, true
+	}
+
+	return 0, false
+}
+
+// ScoreInDiscs converts a score to discs, the exact margin when the search
+// saw the end of the game.
+func ScoreInDiscs(score int) float64 {
+	margin, exact := FinalMargin(score)
+	if exact {
+		return float64(margin)
+	}
+
+	return float64(score) / discValue
+}
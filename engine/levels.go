@@ -0,0 +1,138 @@
+package engine
+
+import (
+	"errors"
+	"math/rand"
+	"reversi/core"
+	"strings"
+	"time"
+)
+
+// LevelEloStep is how much stronger each level is meant to be than the one
+// before it.
+const LevelEloStep = 200
+
+// mistakeChoices is how many of the best moves a level picks from when it
+// makes a mistake, the best one excluded.
+const mistakeChoices = 3
+
+var ErrUnknownLevel = errors.New("unknown level")
+
+// Level is a named difficulty for the computer. The weaker levels search less
+// deeply, misjudge positions by up to Noise and now and then play one of the
+// next best moves instead of the best one.
+type Level struct {
+	Name string
+	// Elo is the strength expected above the weakest level.
+	Elo   int
+	Depth int
+	Time  time.Duration
+	// Noise is the most, in score units, added to or taken from an
+	// evaluation, discValue is about one disc.
+	Noise int
+	// Mistakes is the chance of not playing the best move.
+	Mistakes float64
+}
+
+// Levels go from the weakest to the strongest, LevelEloStep apart give or take
+// 25 as measured against each other with cmd/arena, see the README.
+var Levels = []Level{
+	{Name: "beginner", Elo: 0, Depth: 1, Time: 50 * time.Millisecond, Noise: 350, Mistakes: 0.35},
+	{Name: "novice", Elo: 200, Depth: 2, Time: 100 * time.Millisecond, Noise: 330, Mistakes: 0.33},
+	{Name: "casual", Elo: 400, Depth: 2, Time: 200 * time.Millisecond, Noise: 120, Mistakes: 0.12},
+	{Name: "intermediate", Elo: 600, Depth: 3, Time: 300 * time.Millisecond, Noise: 70, Mistakes: 0.07},
+	{Name: "advanced", Elo: 800, Depth: 4, Time: 500 * time.Millisecond, Noise: 10, Mistakes: 0.01},
+	{Name: "expert", Elo: 1000, Depth: 6, Time: time.Second},
+}
+
+// ParseLevel accepts the level names in any case.
+func ParseLevel(name string) (Level, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, level := range Levels {
+		if level.Name == name {
+			return level, nil
+		}
+	}
+
+	return Level{}, ErrUnknownLevel
+}
+
+// noisyEvaluator adds noise that depends only on the position, so a search
+// sees the same value every time it meets it.
+type noisyEvaluator struct {
+	evaluator Evaluator
+	noise     int
+	seed      uint64
+}
+
+// mix is the splitmix64 finaliser, it spreads the bits of the hash.
+func mix(value uint64) uint64 {
+	value ^= value >> 30
+	value *= 0xbf58476d1ce4e5b9
+	value ^= value >> 27
+	value *= 0x94d049bb133111eb
+	return value ^ value>>31
+}
+
+func (evaluator noisyEvaluator) Evaluate(position Position) int {
+	offset := int(mix(position.Hash^evaluator.seed)%uint64(2*evaluator.noise+1)) - evaluator.noise
+	return evaluator.evaluator.Evaluate(position) + offset
+}
+
+type levelPlayer struct {
+	level    Level
+	searcher *Searcher
+	random   *rand.Rand
+}
+
+// limitsFor keeps to the tighter of the level's limits and the ones given.
+func (player *levelPlayer) limitsFor(limits Limits) Limits {
+	bounded := Limits{Depth: player.level.Depth, Time: player.level.Time}
+	if limits.Depth > 0 && limits.Depth < bounded.Depth {
+		bounded.Depth = limits.Depth
+	}
+	if limits.Time > 0 && limits.Time < bounded.Time {
+		bounded.Time = limits.Time
+	}
+
+	return bounded
+}
+
+// BestMove plays the best move the level finds, unless it makes a mistake
+// and plays one of the next best.
+func (player *levelPlayer) BestMove(gameState core.GameState, limits Limits) (Result, error) {
+	limits = player.limitsFor(limits)
+	if player.random.Float64() >= player.level.Mistakes {
+		return player.searcher.BestMove(gameState, limits)
+	}
+
+	results, err := player.searcher.MultiPV(gameState, limits, mistakeChoices+1)
+	if err != nil {
+		return Result{}, err
+	}
+	if len(results) == 1 {
+		return results[0], nil
+	}
+
+	return results[1+player.random.Intn(len(results)-1)], nil
+}
+
+// NewLevelPlayer plays at the level, the seed makes its noise and mistakes
+// repeatable and 0 picks one from the clock.
+func NewLevelPlayer(level Level, seed int64) Player {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	random := rand.New(rand.NewSource(seed))
+
+	var evaluator Evaluator = NewWeightedSquareEvaluator()
+	if level.Noise > 0 {
+		evaluator = noisyEvaluator{evaluator: evaluator, noise: level.Noise, seed: random.Uint64()}
+	}
+
+	return &levelPlayer{
+		level:    level,
+		searcher: NewSearcher(evaluator),
+		random:   random,
+	}
+}
@@ -0,0 +1,61 @@
+package engine_test
+
+import (
+	"reversi/core"
+	"reversi/engine"
+	"testing"
+)
+
+func Test_Levels_areAFixedEloStepApartAndGetStronger(t *testing.T) {
+	for i := 1; i < len(engine.Levels); i++ {
+		weaker, stronger := engine.Levels[i-1], engine.Levels[i]
+
+		if stronger.Elo-weaker.Elo != engine.LevelEloStep {
+			t.Errorf("Expected %s to be %d Elo above %s, instead %d", stronger.Name, engine.LevelEloStep, weaker.Name, stronger.Elo-weaker.Elo)
+		}
+		if stronger.Depth < weaker.Depth || stronger.Time <= weaker.Time || stronger.Noise >= weaker.Noise || stronger.Mistakes >= weaker.Mistakes {
+			t.Errorf("Expected %+v to be stronger than %+v in every setting", stronger, weaker)
+		}
+	}
+}
+
+func Test_ParseLevel_acceptsAnyCase(t *testing.T) {
+	level, err := engine.ParseLevel(" Intermediate ")
+	if err != nil || level.Name != "intermediate" {
+		t.Errorf("Expected the intermediate level, instead got %+v (%v)", level, err)
+	}
+
+	if _, err := engine.ParseLevel("grandmaster"); err != engine.ErrUnknownLevel {
+		t.Errorf("Expected an unknown level, instead got %v", err)
+	}
+}
+
+func Test_EveryLevel_playsLegalMovesUntilTheEnd(t *testing.T) {
+	for _, level := range engine.Levels {
+		player := engine.NewLevelPlayer(level, 1)
+
+		moves := []core.Coordinate{}
+		state := stateAfter(moves)
+		for !state.IsOver() {
+			result, err := player.BestMove(state, engine.Limits{Depth: 2})
+			if err != nil {
+				t.Fatalf("%s failed to pick a move: %s", level.Name, err.Error())
+			}
+			if !state.MoveOptions()[result.Move] {
+				t.Fatalf("%s picked the illegal move (%d, %d)", level.Name, result.Move.X, result.Move.Y)
+			}
+
+			moves = append(moves, result.Move)
+			state = stateAfter(moves)
+		}
+	}
+}
+
+func Test_StrongestLevel_findsTheWinningMove(t *testing.T) {
+	player := engine.NewLevelPlayer(engine.Levels[len(engine.Levels)-1], 1)
+
+	result, err := player.BestMove(stateAfter(wipeout[:8]), engine.Limits{Depth: 3})
+	if err != nil || result.Move != (core.Coordinate{X: 1, Y: 6}) {
+		t.Errorf("Expected (1, 6) to win the game, instead got %+v (%v)", result, err)
+	}
+}
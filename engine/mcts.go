@@ -0,0 +1,287 @@
+package engine
+
+import (
+	"math"
+	"math/bits"
+	"math/rand"
+	"reversi/core"
+	"time"
+)
+
+const (
+	defaultIterations  = 10000
+	defaultExploration = 1.4
+)
+
+type PlayoutPolicy string
+
+const (
+	RANDOM_PLAYOUTS PlayoutPolicy = "RANDOM"
+	GUIDED_PLAYOUTS PlayoutPolicy = "GUIDED"
+)
+
+const (
+	corners   = uint64(0x8100000000000081)
+	xSquares  = uint64(0x0042000000004200)
+	playAlong = 0.9
+)
+
+type MCTSOptions struct {
+	Exploration float64
+	Playouts    PlayoutPolicy
+	Seed        int64
+}
+
+type mctsNode struct {
+	position Position
+	move     int
+	parent   *mctsNode
+	children []*mctsNode
+	untried  []int
+	visits   float64
+	// wins counts results for the side that moved into this node.
+	wins float64
+}
+
+// MCTSPlayer picks moves with Monte Carlo tree search and keeps the tree
+// between moves, so the part of it that is still reachable is not searched
+// again.
+type MCTSPlayer struct {
+	options MCTSOptions
+	random  *rand.Rand
+	root    *mctsNode
+}
+
+func newNode(position Position, move int, parent *mctsNode) *mctsNode {
+	node := &mctsNode{
+		position: position,
+		move:     move,
+		parent:   parent,
+	}
+
+	moves := position.Moves()
+	if moves != 0 {
+		node.untried = moveList(moves)
+	} else if position.OpponentMoves() != 0 {
+		node.untried = []int{passMove}
+	}
+
+	return node
+}
+
+func (node *mctsNode) play(move int) Position {
+	if move == passMove {
+		return node.position.Pass()
+	}
+
+	return node.position.Play(move)
+}
+
+func (node *mctsNode) uct(exploration float64) *mctsNode {
+	var best *mctsNode
+	bestValue := math.Inf(-1)
+	logVisits := math.Log(node.visits)
+
+	for _, child := range node.children {
+		value := child.wins/child.visits + exploration*math.Sqrt(logVisits/child.visits)
+		if value > bestValue {
+			best = child
+			bestValue = value
+		}
+	}
+
+	return best
+}
+
+func (node *mctsNode) mostVisited() *mctsNode {
+	var best *mctsNode
+	for _, child := range node.children {
+		if best == nil || child.visits > best.visits {
+			best = child
+		}
+	}
+
+	return best
+}
+
+func randomBit(moves uint64, random *rand.Rand) int {
+	skip := random.Intn(bits.OnesCount64(moves))
+	for i := 0; i < skip; i++ {
+		moves &= moves - 1
+	}
+
+	return bits.TrailingZeros64(moves)
+}
+
+// guidedMove takes corners when it can and avoids the squares next to the
+// corners when there is anything else to play.
+func guidedMove(moves uint64, random *rand.Rand) int {
+	if moves&corners != 0 && random.Float64() < playAlong {
+		return randomBit(moves&corners, random)
+	}
+	if moves&^xSquares != 0 && random.Float64() < playAlong {
+		return randomBit(moves&^xSquares, random)
+	}
+
+	return randomBit(moves, random)
+}
+
+func (player *MCTSPlayer) playout(position Position) core.Player {
+	for {
+		moves := position.Moves()
+		if moves == 0 {
+			if position.OpponentMoves() == 0 {
+				break
+			}
+			position = position.Pass()
+			continue
+		}
+
+		if player.options.Playouts == GUIDED_PLAYOUTS {
+			position = position.Play(guidedMove(moves, player.random))
+		} else {
+			position = position.Play(randomBit(moves, player.random))
+		}
+	}
+
+	difference := position.DiscDifference()
+	if difference > 0 {
+		return position.Side
+	}
+	if difference < 0 {
+		return position.Side.Opposite()
+	}
+
+	return ""
+}
+
+func (player *MCTSPlayer) iterate(root *mctsNode) {
+	node := root
+	for len(node.untried) == 0 && len(node.children) > 0 {
+		node = node.uct(player.options.Exploration)
+	}
+
+	if len(node.untried) > 0 {
+		pick := player.random.Intn(len(node.untried))
+		move := node.untried[pick]
+		node.untried[pick] = node.untried[len(node.untried)-1]
+		node.untried = node.untried[:len(node.untried)-1]
+
+		child := newNode(node.play(move), move, node)
+		node.children = append(node.children, child)
+		node = child
+	}
+
+	winner := player.playout(node.position)
+
+	for ; node != nil; node = node.parent {
+		node.visits++
+		if node.parent == nil {
+			continue
+		}
+
+		mover := node.parent.position.Side
+		if winner == mover {
+			node.wins++
+		} else if winner == "" {
+			node.wins += 0.5
+		}
+	}
+}
+
+// reusableRoot looks for the position among the nodes reached by our last
+// move and the reply to it, passes included.
+func (player *MCTSPlayer) reusableRoot(position Position) *mctsNode {
+	if player.root == nil {
+		return nil
+	}
+
+	frontier := []*mctsNode{player.root}
+	for depth := 0; depth < 3; depth++ {
+		next := []*mctsNode{}
+		for _, node := range frontier {
+			if node.position == position {
+				node.parent = nil
+				return node
+			}
+			next = append(next, node.children...)
+		}
+		frontier = next
+	}
+
+	return nil
+}
+
+func (player *MCTSPlayer) principalVariation(root *mctsNode) []int {
+	line := []int{}
+	for node := root.mostVisited(); node != nil; node = node.mostVisited() {
+		line = append(line, node.move)
+	}
+
+	return line
+}
+
+func (player *MCTSPlayer) BestMove(gameState core.GameState, limits Limits) (Result, error) {
+	position := NewPosition(gameState)
+	if position.Moves() == 0 {
+		return Result{}, ErrNoMoves
+	}
+
+	started := time.Now()
+
+	root := player.reusableRoot(position)
+	if root == nil {
+		root = newNode(position, passMove, nil)
+	}
+	player.root = root
+
+	iterations := limits.Iterations
+	if iterations <= 0 && limits.Time <= 0 {
+		iterations = defaultIterations
+	}
+
+	count := 0
+	for {
+		player.iterate(root)
+		count++
+
+		if iterations > 0 && count >= iterations {
+			break
+		}
+		if limits.Time > 0 && time.Since(started) >= limits.Time {
+			break
+		}
+	}
+
+	best := root.mostVisited()
+	line := player.principalVariation(root)
+
+	return Result{
+		Move:               coordinateOf(best.move),
+		Depth:              len(line),
+		PrincipalVariation: toCoordinates(line),
+		Nodes:              uint64(count),
+		Elapsed:            time.Since(started),
+		WinRate:            best.wins / best.visits,
+		Visits:             uint64(best.visits),
+	}, nil
+}
+
+func NewMCTSPlayer(options MCTSOptions) *MCTSPlayer {
+	if options.Exploration <= 0 {
+		options.Exploration = defaultExploration
+	}
+	if options.Playouts == "" {
+		options.Playouts = RANDOM_PLAYOUTS
+	}
+
+	seed := options.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	return &MCTSPlayer{
+		options: options,
+		random:  rand.New(rand.NewSource(seed)),
+	}
+}
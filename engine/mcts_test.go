@@ -0,0 +1,55 @@
+package engine_test
+
+import (
+	"reversi/core"
+	"reversi/engine"
+	"testing"
+)
+
+func Test_MCTSPlayer_findsTheWinningMove(t *testing.T) {
+	player := engine.NewMCTSPlayer(engine.MCTSOptions{Seed: 1})
+	state := stateAfter(wipeout[:8])
+
+	result, err := player.BestMove(state, engine.Limits{Iterations: 2000})
+	if err != nil {
+		t.Fatalf("Expected a move, instead got %s", err.Error())
+	}
+
+	if result.Move != (core.Coordinate{X: 1, Y: 6}) {
+		t.Errorf("Expected (1, 6) to win the game, instead got (%d, %d)", result.Move.X, result.Move.Y)
+	}
+	if result.WinRate < 0.99 {
+		t.Errorf("A winning move should win every playout, instead won %f", result.WinRate)
+	}
+}
+
+func Test_MCTSPlayer_guidedPlayouts_returnLegalMoves(t *testing.T) {
+	player := engine.NewMCTSPlayer(engine.MCTSOptions{Seed: 2, Playouts: engine.GUIDED_PLAYOUTS})
+	state := stateAfter(wholeGame[:30])
+
+	result, err := player.BestMove(state, engine.Limits{Iterations: 500})
+	if err != nil {
+		t.Fatalf("Expected a move, instead got %s", err.Error())
+	}
+
+	if !state.MoveOptions()[result.Move] {
+		t.Errorf("(%d, %d) is not a legal move", result.Move.X, result.Move.Y)
+	}
+}
+
+func Test_MCTSPlayer_reusesTheTreeAfterTheOpponentReplies(t *testing.T) {
+	player := engine.NewMCTSPlayer(engine.MCTSOptions{Seed: 3})
+	opening := []core.Coordinate{}
+
+	first, _ := player.BestMove(stateAfter(opening), engine.Limits{Iterations: 5000})
+
+	moves := append(opening, first.PrincipalVariation[0], first.PrincipalVariation[1])
+	second, err := player.BestMove(stateAfter(moves), engine.Limits{Iterations: 1})
+	if err != nil {
+		t.Fatalf("Expected a move, instead got %s", err.Error())
+	}
+
+	if second.Visits <= 1 {
+		t.Errorf("The expected line should have been explored before, instead the move was visited %d times", second.Visits)
+	}
+}
@@ -0,0 +1,100 @@
+package engine
+
+import (
+	"context"
+	"reversi/core"
+	"sort"
+	"time"
+)
+
+// AllMoves asks MultiPV for a score for every legal move.
+const AllMoves = 0
+
+type rootMove struct {
+	move  int
+	score int
+	line  []int
+}
+
+// searchRoot searches every move of the position to the depth. Once count
+// moves are known only moves that can beat the last of them are searched
+// exactly, the rest fail low and are left out.
+func (search *search) searchRoot(position Position, moves []int, depth int, count int) []rootMove {
+	searched := []rootMove{}
+	for _, move := range moves {
+		alpha := -infinity
+		if count != AllMoves && len(searched) >= count {
+			alpha = searched[count-1].score
+		}
+
+		var child []int
+		score := -search.negamax(position.Play(move), depth-1, -infinity, -alpha, 1, &child)
+		if search.aborted {
+			return nil
+		}
+		if score <= alpha && alpha != -infinity {
+			continue
+		}
+
+		searched = append(searched, rootMove{move: move, score: score, line: append([]int{move}, child...)})
+		sort.SliceStable(searched, func(i, j int) bool {
+			return searched[i].score > searched[j].score
+		})
+	}
+
+	if count != AllMoves && len(searched) > count {
+		searched = searched[:count]
+	}
+	return searched
+}
+
+// MultiPV searches like BestMove but reports the best count moves, each with
+// its score and principal variation, the best first. AllMoves scores every
+// legal move.
+func (searcher *Searcher) MultiPV(gameState core.GameState, limits Limits, count int) ([]Result, error) {
+	position := NewPosition(gameState)
+	moves := moveList(position.Moves())
+	if len(moves) == 0 {
+		return nil, ErrNoMoves
+	}
+
+	started := time.Now()
+	searcher.table.NewSearch()
+	search := newSearch(context.Background(), searcher.evaluator, searcher.table, limits, started)
+
+	best := []rootMove{}
+	completed := 0
+	for depth := 1; depth <= maximumDepth(position, limits); depth++ {
+		searched := search.searchRoot(position, moves, depth, count)
+		if search.aborted {
+			break
+		}
+
+		best, completed = searched, depth
+		search.canAbort = true
+
+		// The next depth starts with the best moves so far, they raise the
+		// bar the others have to clear soonest.
+		order := make(map[int]int)
+		for rank, root := range searched {
+			order[root.move] = len(searched) - rank
+		}
+		sort.SliceStable(moves, func(i, j int) bool {
+			return order[moves[i]] > order[moves[j]]
+		})
+	}
+
+	results := []Result{}
+	for _, root := range best {
+		results = append(results, Result{
+			Move:               coordinateOf(root.move),
+			Score:              root.score,
+			Depth:              completed,
+			PrincipalVariation: toCoordinates(root.line),
+			Nodes:              search.nodes,
+			Elapsed:            time.Since(started),
+		})
+	}
+
+	return results, nil
+}
@@ -0,0 +1,64 @@
+package engine_test
+
+import (
+	"reversi/core"
+	"reversi/engine"
+	"testing"
+)
+
+func multiPV(t *testing.T, state core.GameState, count int) []engine.Result {
+	results, err := engine.NewSearcher(engine.NewWeightedSquareEvaluator()).MultiPV(state, engine.Limits{Depth: 4}, count)
+	if err != nil {
+		t.Fatalf("Expected the moves to be searched, instead got %s", err.Error())
+	}
+
+	return results
+}
+
+func Test_MultiPV_forAllMoves_ranksEveryMoveLikeTheSearch(t *testing.T) {
+	state := stateAfter(wholeGame[:20])
+	results := multiPV(t, state, engine.AllMoves)
+
+	if len(results) != len(state.MoveOptions()) {
+		t.Fatalf("Expected a score for each of the %d moves, instead got %d", len(state.MoveOptions()), len(results))
+	}
+	for i, result := range results {
+		if !state.MoveOptions()[result.Move] {
+			t.Errorf("(%d, %d) is not a legal move", result.Move.X, result.Move.Y)
+		}
+		if i > 0 && result.Score > results[i-1].Score {
+			t.Errorf("The moves should be listed from the best, got %d after %d", result.Score, results[i-1].Score)
+		}
+	}
+
+	best, _ := engine.BestMove(state, engine.Limits{Depth: 4})
+	if results[0].Score != best.Score {
+		t.Errorf("Expected the best move to score %d like the search, instead got %d", best.Score, results[0].Score)
+	}
+}
+
+func Test_MultiPV_reportsTheBestFewWithTheirLines(t *testing.T) {
+	state := stateAfter(wholeGame[:20])
+	all := multiPV(t, state, engine.AllMoves)
+	top := multiPV(t, state, 3)
+
+	if len(top) != 3 {
+		t.Fatalf("Expected 3 moves, instead got %d", len(top))
+	}
+	for i, result := range top {
+		if result.Score != all[i].Score {
+			t.Errorf("Expected move %d to score %d like with all moves, instead got %d", i+1, all[i].Score, result.Score)
+		}
+		if result.Depth != 4 || len(result.PrincipalVariation) == 0 || result.PrincipalVariation[0] != result.Move {
+			t.Fatalf("Expected a depth 4 line starting with the move, instead got %+v", result)
+		}
+
+		moves := append(append([]core.Coordinate{}, wholeGame[:20]...), result.PrincipalVariation...)
+		states := replay(moves)
+		for ply := 20; ply < len(moves); ply++ {
+			if !states[ply].MoveOptions()[moves[ply]] {
+				t.Fatalf("Move %d of the line of (%d, %d) is not legal", ply-19, result.Move.X, result.Move.Y)
+			}
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package engine
+
+import (
+	"context"
+	"reversi/core"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// ParallelSearcher is a Lazy SMP search: every worker searches the same
+// position and they share one table, so what one worker learns speeds up
+// the others. Odd workers start a depth further on to spread the work.
+type ParallelSearcher struct {
+	evaluator Evaluator
+	table     *TranspositionTable
+	workers   int
+}
+
+func (searcher *ParallelSearcher) Workers() int {
+	return searcher.workers
+}
+
+// Search stops when the limits are reached or the context is done and
+// reports the deepest search any worker completed, with the nodes of all of
+// them.
+func (searcher *ParallelSearcher) Search(ctx context.Context, gameState core.GameState, limits Limits) (Result, error) {
+	position := NewPosition(gameState)
+	if position.Moves() == 0 {
+		return Result{}, ErrNoMoves
+	}
+
+	started := time.Now()
+	searcher.table.NewSearch()
+
+	// The helpers stop as soon as the first worker is done.
+	ctx, stop := context.WithCancel(ctx)
+	defer stop()
+
+	results := make([]Result, searcher.workers)
+	nodes := make([]uint64, searcher.workers)
+
+	var group sync.WaitGroup
+	for worker := 0; worker < searcher.workers; worker++ {
+		group.Add(1)
+		go func(worker int) {
+			defer group.Done()
+
+			search := newSearch(ctx, searcher.evaluator, searcher.table, limits, started)
+			// Only the first worker has to finish a depth to have a move to
+			// report, a helper stopped part way through reports nothing.
+			search.canAbort = worker > 0
+			results[worker] = search.deepen(position, limits, 1+worker%2)
+			nodes[worker] = search.nodes
+
+			if worker == 0 {
+				stop()
+			}
+		}(worker)
+	}
+	group.Wait()
+
+	best := results[0]
+	total := uint64(0)
+	for worker, result := range results {
+		if result.Depth > best.Depth {
+			best = result
+		}
+		total += nodes[worker]
+	}
+
+	best.Nodes = total
+	best.Elapsed = time.Since(started)
+	return best, nil
+}
+
+func (searcher *ParallelSearcher) BestMove(gameState core.GameState, limits Limits) (Result, error) {
+	return searcher.Search(context.Background(), gameState, limits)
+}
+
+// NewParallelSearcher uses one worker for every CPU when workers is 0.
+func NewParallelSearcher(evaluator Evaluator, workers int) *ParallelSearcher {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	return &ParallelSearcher{
+		evaluator: evaluator,
+		table:     NewTranspositionTable(defaultTableMegabytes),
+		workers:   workers,
+	}
+}
@@ -0,0 +1,58 @@
+package engine_test
+
+import (
+	"context"
+	"reversi/core"
+	"reversi/engine"
+	"testing"
+	"time"
+)
+
+func Test_ParallelSearch_findsTheWinningMove(t *testing.T) {
+	state := stateAfter(wipeout[:8])
+
+	result, err := engine.NewParallelSearcher(engine.NewWeightedSquareEvaluator(), 4).BestMove(state, engine.Limits{Depth: 3})
+	if err != nil {
+		t.Fatalf("Expected a move, instead got %s", err.Error())
+	}
+
+	if result.Move != (core.Coordinate{X: 1, Y: 6}) {
+		t.Errorf("Expected (1, 6) to win the game, instead got (%d, %d)", result.Move.X, result.Move.Y)
+	}
+}
+
+func Test_ParallelSearch_reportsNodesPerSecond(t *testing.T) {
+	state := stateAfter(wholeGame[:20])
+
+	result, err := engine.NewParallelSearcher(engine.NewWeightedSquareEvaluator(), 4).BestMove(state, engine.Limits{Time: 200 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("Expected a move, instead got %s", err.Error())
+	}
+
+	if !state.MoveOptions()[result.Move] || result.Depth < 1 {
+		t.Fatalf("Expected a legal move from a completed search, instead got %+v", result)
+	}
+
+	expected := float64(result.Nodes) / result.Elapsed.Seconds()
+	if result.Nodes == 0 || result.NodesPerSecond() != expected {
+		t.Errorf("Expected %.0f nodes a second, instead got %.0f", expected, result.NodesPerSecond())
+	}
+}
+
+func Test_ParallelSearch_stopsWhenTheContextIsDone(t *testing.T) {
+	state := stateAfter(nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	started := time.Now()
+	// Without a time limit the search would go on to the end of the game.
+	result, err := engine.NewParallelSearcher(engine.NewWeightedSquareEvaluator(), 2).Search(ctx, state, engine.Limits{Depth: 60})
+	elapsed := time.Since(started)
+
+	if err != nil || !state.MoveOptions()[result.Move] {
+		t.Fatalf("Expected a legal move, instead got %+v (%v)", result, err)
+	}
+	if elapsed > time.Second {
+		t.Errorf("Expected the search to stop soon after the context, instead it took %s", elapsed)
+	}
+}
@@ -0,0 +1,137 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"reversi/core"
+	"time"
+)
+
+const (
+	defaultEpochs       = 10
+	defaultLearningRate = 0.2
+)
+
+var (
+	ErrIllegalTrainingMove = core.ErrIllegalMove
+	ErrUnfinishedGame      = errors.New("a training game is not finished")
+)
+
+type TrainingOptions struct {
+	// Phases splits the game by the number of discs, DefaultPhases when 0.
+	Phases int
+	Epochs int
+	// LearningRate is the share of the error corrected at every position.
+	LearningRate float64
+	// Seed shuffles the positions, 0 picks one from the clock.
+	Seed int64
+}
+
+// sample is a position with the final disc difference for the side to move.
+type sample struct {
+	phase          int
+	mobility       float64
+	configurations []int32
+	target         float64
+}
+
+func samplesOf(moves []core.Coordinate, phases int) ([]sample, error) {
+	states, err := core.Replay(core.StartingPosition(), moves)
+	if err != nil {
+		return nil, err
+	}
+
+	final := states[len(states)-1]
+	if !final.IsOver() {
+		return nil, ErrUnfinishedGame
+	}
+	blackMargin := float64(final.DiscCount(core.BLACK) - final.DiscCount(core.WHITE))
+
+	samples := []sample{}
+	for _, state := range states[:len(states)-1] {
+		position := NewPosition(state)
+
+		configurations := make([]int32, len(instances))
+		for i, instance := range instances {
+			configurations[i] = int32(instance.configuration(position))
+		}
+
+		target := blackMargin
+		if position.Side == core.WHITE {
+			target = -target
+		}
+
+		samples = append(samples, sample{
+			phase:          phaseOf(position, phases),
+			mobility:       float64(mobilityOf(position)),
+			configurations: configurations,
+			target:         target,
+		})
+	}
+
+	return samples, nil
+}
+
+// TrainPatterns fits pattern weights to the final disc difference of the
+// games, every position of every game is one example. progress, when given,
+// is told the mean squared error of each epoch.
+func TrainPatterns(games [][]core.Coordinate, options TrainingOptions, progress func(epoch int, meanSquaredError float64)) (PatternWeights, error) {
+	if options.Phases <= 0 {
+		options.Phases = DefaultPhases
+	}
+	if options.Epochs <= 0 {
+		options.Epochs = defaultEpochs
+	}
+	if options.LearningRate <= 0 {
+		options.LearningRate = defaultLearningRate
+	}
+	if options.Seed == 0 {
+		options.Seed = time.Now().UnixNano()
+	}
+
+	samples := []sample{}
+	for i, moves := range games {
+		gameSamples, err := samplesOf(moves, options.Phases)
+		if err != nil {
+			return PatternWeights{}, fmt.Errorf("game %d: %w", i+1, err)
+		}
+		samples = append(samples, gameSamples...)
+	}
+
+	evaluator := newPatternEvaluator(options.Phases)
+	random := rand.New(rand.NewSource(options.Seed))
+	// The bias and mobility count as two more weights when the correction is
+	// shared out.
+	share := options.LearningRate / float64(len(instances)+2)
+
+	for epoch := 1; epoch <= options.Epochs; epoch++ {
+		random.Shuffle(len(samples), func(i, j int) {
+			samples[i], samples[j] = samples[j], samples[i]
+		})
+
+		squaredError := 0.0
+		for _, sample := range samples {
+			predicted := evaluator.bias[sample.phase] + evaluator.mobility[sample.phase]*sample.mobility
+			for i, instance := range instances {
+				predicted += evaluator.weights[instance.pattern][sample.phase][sample.configurations[i]]
+			}
+
+			difference := sample.target - predicted
+			squaredError += difference * difference
+
+			step := share * difference
+			evaluator.bias[sample.phase] += step
+			evaluator.mobility[sample.phase] += step * sample.mobility / mobilityScale
+			for i, instance := range instances {
+				evaluator.weights[instance.pattern][sample.phase][sample.configurations[i]] += step
+			}
+		}
+
+		if progress != nil && len(samples) > 0 {
+			progress(epoch, squaredError/float64(len(samples)))
+		}
+	}
+
+	return evaluator.patternWeights(), nil
+}
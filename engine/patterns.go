@@ -0,0 +1,302 @@
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"math/bits"
+	"os"
+	"reversi/core"
+	"sort"
+)
+
+const (
+	DefaultPhases = 4
+	// mobilityScale keeps the mobility weight learning at about the pace of
+	// the pattern weights, mobility differences run to around ten moves.
+	mobilityScale = 10.0
+)
+
+var ErrBadWeights = errors.New("the pattern weights do not fit the patterns")
+
+type pattern struct {
+	name    string
+	squares []core.Coordinate
+}
+
+func line(fromX int, fromY int, stepX int, stepY int, length int) []core.Coordinate {
+	squares := []core.Coordinate{}
+	for i := 0; i < length; i++ {
+		squares = append(squares, core.Coordinate{X: fromX + i*stepX, Y: fromY + i*stepY})
+	}
+
+	return squares
+}
+
+// patterns are drawn in the top left of the board, every turn and mirror of
+// them shares the same weights.
+var patterns = []pattern{
+	{"edge+2x", append(line(0, 0, 1, 0, 8), core.Coordinate{X: 1, Y: 1}, core.Coordinate{X: 6, Y: 1})},
+	{"corner3x3", append(append(line(0, 0, 1, 0, 3), line(0, 1, 1, 0, 3)...), line(0, 2, 1, 0, 3)...)},
+	{"corner2x5", append(line(0, 0, 1, 0, 5), line(0, 1, 1, 0, 5)...)},
+	{"line2", line(0, 1, 1, 0, 8)},
+	{"line3", line(0, 2, 1, 0, 8)},
+	{"line4", line(0, 3, 1, 0, 8)},
+	{"diagonal8", line(0, 0, 1, 1, 8)},
+	{"diagonal7", line(0, 1, 1, 1, 7)},
+	{"diagonal6", line(0, 2, 1, 1, 6)},
+	{"diagonal5", line(0, 3, 1, 1, 5)},
+	{"diagonal4", line(0, 4, 1, 1, 4)},
+}
+
+var boardSymmetries = []func(core.Coordinate) core.Coordinate{
+	func(c core.Coordinate) core.Coordinate { return c },
+	func(c core.Coordinate) core.Coordinate { return core.Coordinate{X: 7 - c.Y, Y: c.X} },
+	func(c core.Coordinate) core.Coordinate { return core.Coordinate{X: 7 - c.X, Y: 7 - c.Y} },
+	func(c core.Coordinate) core.Coordinate { return core.Coordinate{X: c.Y, Y: 7 - c.X} },
+	func(c core.Coordinate) core.Coordinate { return core.Coordinate{X: 7 - c.X, Y: c.Y} },
+	func(c core.Coordinate) core.Coordinate { return core.Coordinate{X: c.X, Y: 7 - c.Y} },
+	func(c core.Coordinate) core.Coordinate { return core.Coordinate{X: c.Y, Y: c.X} },
+	func(c core.Coordinate) core.Coordinate { return core.Coordinate{X: 7 - c.Y, Y: 7 - c.X} },
+}
+
+// instance is one place a pattern is found on the board. Turns of the board
+// that bring the pattern back onto the same squares, such as reversing a line,
+// are kept as further orders of the same instance rather than counted again.
+type instance struct {
+	pattern int
+	// orders are bits of a Position, each in the order of the pattern.
+	orders [][]uint
+}
+
+var (
+	instances    []instance
+	patternSizes []int
+)
+
+func init() {
+	for index, pattern := range patterns {
+		patternSizes = append(patternSizes, int(math.Pow(3, float64(len(pattern.squares)))))
+
+		places := make(map[string]int)
+		for _, symmetry := range boardSymmetries {
+			squares := []uint{}
+			for _, square := range pattern.squares {
+				squares = append(squares, uint(indexOf(symmetry(square))))
+			}
+
+			sorted := append([]uint{}, squares...)
+			sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+			key := fmt.Sprint(sorted)
+
+			place, seen := places[key]
+			if !seen {
+				places[key] = len(instances)
+				instances = append(instances, instance{pattern: index, orders: [][]uint{squares}})
+				continue
+			}
+			if !containsOrder(instances[place].orders, squares) {
+				instances[place].orders = append(instances[place].orders, squares)
+			}
+		}
+	}
+}
+
+func containsOrder(orders [][]uint, squares []uint) bool {
+	for _, order := range orders {
+		if fmt.Sprint(order) == fmt.Sprint(squares) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// configuration numbers what is on the squares in base 3, an empty square is
+// 0, the side to move 1 and the opponent 2.
+func configuration(position Position, squares []uint) int {
+	result := 0
+	for _, square := range squares {
+		result *= 3
+		if position.Player>>square&1 != 0 {
+			result += 1
+		} else if position.Opponent>>square&1 != 0 {
+			result += 2
+		}
+	}
+
+	return result
+}
+
+// configuration of an instance is the smallest over its orders, so a turned
+// position reads the same configuration.
+func (place instance) configuration(position Position) int {
+	smallest := configuration(position, place.orders[0])
+	for _, order := range place.orders[1:] {
+		if other := configuration(position, order); other < smallest {
+			smallest = other
+		}
+	}
+
+	return smallest
+}
+
+func phaseOf(position Position, phases int) int {
+	discs := bits.OnesCount64(position.Player | position.Opponent)
+	phase := (discs - 4) * phases / 61
+	if phase < 0 {
+		return 0
+	}
+	if phase >= phases {
+		return phases - 1
+	}
+
+	return phase
+}
+
+// PatternWeights are what a pattern evaluator learned, in discs for the side
+// to move. Mobility is worth per move more than the opponent has. Tables
+// holds for each pattern and phase only the configurations that have a
+// weight.
+type PatternWeights struct {
+	Phases   int
+	Bias     []float64
+	Mobility []float64
+	Tables   map[string][]map[int]float64
+}
+
+func (weights PatternWeights) Save(path string) error {
+	data, err := json.Marshal(weights)
+	if err != nil {
+		return err
+	}
+
+	temporary := path + ".tmp"
+	err = ioutil.WriteFile(temporary, data, 0644)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(temporary, path)
+}
+
+func LoadPatternWeights(path string) (PatternWeights, error) {
+	weights := PatternWeights{}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return weights, err
+	}
+
+	err = json.Unmarshal(data, &weights)
+	return weights, err
+}
+
+type patternEvaluator struct {
+	phases   int
+	bias     []float64
+	mobility []float64
+	// weights are by pattern, then phase, then configuration.
+	weights [][][]float64
+}
+
+func (evaluator *patternEvaluator) Evaluate(position Position) int {
+	phase := phaseOf(position, evaluator.phases)
+
+	total := evaluator.bias[phase] + evaluator.mobility[phase]*float64(mobilityOf(position))
+	for _, instance := range instances {
+		total += evaluator.weights[instance.pattern][phase][instance.configuration(position)]
+	}
+
+	return int(math.Round(total * discValue))
+}
+
+func mobilityOf(position Position) int {
+	return bits.OnesCount64(position.Moves()) - bits.OnesCount64(position.OpponentMoves())
+}
+
+func newPatternEvaluator(phases int) *patternEvaluator {
+	evaluator := &patternEvaluator{phases: phases, bias: make([]float64, phases), mobility: make([]float64, phases)}
+	for index := range patterns {
+		tables := make([][]float64, phases)
+		for phase := range tables {
+			tables[phase] = make([]float64, patternSizes[index])
+		}
+		evaluator.weights = append(evaluator.weights, tables)
+	}
+
+	return evaluator
+}
+
+func (evaluator *patternEvaluator) patternWeights() PatternWeights {
+	weights := PatternWeights{
+		Phases:   evaluator.phases,
+		Bias:     append([]float64{}, evaluator.bias...),
+		Mobility: append([]float64{}, evaluator.mobility...),
+		Tables:   make(map[string][]map[int]float64),
+	}
+
+	for index, pattern := range patterns {
+		tables := make([]map[int]float64, evaluator.phases)
+		for phase := range tables {
+			tables[phase] = make(map[int]float64)
+			for configuration, weight := range evaluator.weights[index][phase] {
+				if weight != 0 {
+					tables[phase][configuration] = weight
+				}
+			}
+		}
+		weights.Tables[pattern.name] = tables
+	}
+
+	return weights
+}
+
+// NewPatternEvaluator scores positions with trained weights, patterns and
+// mobility the weights leave out score nothing.
+func NewPatternEvaluator(weights PatternWeights) (Evaluator, error) {
+	if weights.Phases <= 0 || len(weights.Bias) != weights.Phases {
+		return nil, fmt.Errorf("%w: %d phases with %d biases", ErrBadWeights, weights.Phases, len(weights.Bias))
+	}
+	if weights.Mobility != nil && len(weights.Mobility) != weights.Phases {
+		return nil, fmt.Errorf("%w: %d phases with %d mobility weights", ErrBadWeights, weights.Phases, len(weights.Mobility))
+	}
+
+	evaluator := newPatternEvaluator(weights.Phases)
+	copy(evaluator.bias, weights.Bias)
+	copy(evaluator.mobility, weights.Mobility)
+
+	known := make(map[string]int)
+	for index, pattern := range patterns {
+		known[pattern.name] = index
+	}
+
+	for name, tables := range weights.Tables {
+		index, found := known[name]
+		if !found || len(tables) != weights.Phases {
+			return nil, fmt.Errorf("%w: pattern %s", ErrBadWeights, name)
+		}
+
+		for phase, table := range tables {
+			for configuration, weight := range table {
+				if configuration < 0 || configuration >= patternSizes[index] {
+					return nil, fmt.Errorf("%w: pattern %s has no configuration %d", ErrBadWeights, name, configuration)
+				}
+				evaluator.weights[index][phase][configuration] = weight
+			}
+		}
+	}
+
+	return evaluator, nil
+}
+
+func LoadPatternEvaluator(path string) (Evaluator, error) {
+	weights, err := LoadPatternWeights(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPatternEvaluator(weights)
+}
@@ -0,0 +1,129 @@
+package engine_test
+
+import (
+	"errors"
+	"path/filepath"
+	"reversi/core"
+	"reversi/engine"
+	"testing"
+)
+
+func trainOnWholeGame(t *testing.T, epochs int, progress func(int, float64)) engine.PatternWeights {
+	weights, err := engine.TrainPatterns([][]core.Coordinate{wholeGame}, engine.TrainingOptions{Epochs: epochs, Seed: 1}, progress)
+	if err != nil {
+		t.Fatalf("Expected the weights to be trained, instead got %s", err.Error())
+	}
+
+	return weights
+}
+
+func Test_TrainPatterns_fitsTheGamesBetterEveryEpoch(t *testing.T) {
+	meanErrors := []float64{}
+	trainOnWholeGame(t, 5, func(epoch int, meanSquaredError float64) {
+		meanErrors = append(meanErrors, meanSquaredError)
+	})
+
+	if len(meanErrors) != 5 {
+		t.Fatalf("Expected progress for each of the 5 epochs, instead got %d", len(meanErrors))
+	}
+	if meanErrors[4] >= meanErrors[0]/4 {
+		t.Errorf("Expected the error to fall, instead got %v", meanErrors)
+	}
+}
+
+func Test_PatternEvaluator_scoresTurnedPositionsTheSame(t *testing.T) {
+	evaluator, err := engine.NewPatternEvaluator(trainOnWholeGame(t, 3, nil))
+	if err != nil {
+		t.Fatalf("Expected an evaluator, instead got %s", err.Error())
+	}
+
+	turned := []core.Coordinate{}
+	for _, move := range wholeGame {
+		turned = append(turned, core.Coordinate{X: move.Y, Y: move.X})
+	}
+
+	for _, played := range []int{5, 20, 40} {
+		original := evaluator.Evaluate(engine.NewPosition(stateAfter(wholeGame[:played])))
+		transposed := evaluator.Evaluate(engine.NewPosition(stateAfter(turned[:played])))
+		if original != transposed {
+			t.Errorf("After %d moves the transposed position scored %d instead of %d", played, transposed, original)
+		}
+	}
+}
+
+func Test_SavedPatternWeights_canBeLoadedBySearchPlayers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "weights.json")
+	weights := trainOnWholeGame(t, 2, nil)
+
+	err := weights.Save(path)
+	if err != nil {
+		t.Fatalf("Expected the weights to be saved, instead got %s", err.Error())
+	}
+
+	loaded, err := engine.LoadPatternEvaluator(path)
+	if err != nil {
+		t.Fatalf("Expected the weights to load, instead got %s", err.Error())
+	}
+	trained, _ := engine.NewPatternEvaluator(weights)
+
+	state := stateAfter(wholeGame[:30])
+	if loaded.Evaluate(engine.NewPosition(state)) != trained.Evaluate(engine.NewPosition(state)) {
+		t.Errorf("Expected the loaded weights to score like the trained ones")
+	}
+
+	result, err := engine.NewSearcher(loaded).BestMove(state, engine.Limits{Depth: 3})
+	if err != nil || !state.MoveOptions()[result.Move] {
+		t.Errorf("Expected a legal move from a search with the weights, instead got %+v (%v)", result, err)
+	}
+}
+
+func Test_PatternWeights_thatDoNotFit_areRejected(t *testing.T) {
+	cases := []engine.PatternWeights{
+		{},
+		{Phases: 2, Bias: []float64{0}},
+		{Phases: 1, Bias: []float64{0}, Tables: map[string][]map[int]float64{"triangle": {{}}}},
+		{Phases: 1, Bias: []float64{0}, Tables: map[string][]map[int]float64{"diagonal4": {{81: 1}}}},
+	}
+
+	for _, weights := range cases {
+		_, err := engine.NewPatternEvaluator(weights)
+		if !errors.Is(err, engine.ErrBadWeights) {
+			t.Errorf("Expected %+v to be rejected, instead got %v", weights, err)
+		}
+	}
+}
+
+func Test_TrainPatterns_onAnUnfinishedGame_fails(t *testing.T) {
+	_, err := engine.TrainPatterns([][]core.Coordinate{wholeGame[:10]}, engine.TrainingOptions{}, nil)
+	if !errors.Is(err, engine.ErrUnfinishedGame) {
+		t.Errorf("Expected an unfinished game error, instead got %v", err)
+	}
+}
+
+func Test_EveryPattern_isCountedOnceWhereverItIsFound(t *testing.T) {
+	expected := map[string]int{
+		"edge+2x": 4, "corner3x3": 4, "corner2x5": 8, "line2": 4, "line3": 4, "line4": 4,
+		"diagonal8": 2, "diagonal7": 4, "diagonal6": 4, "diagonal5": 4, "diagonal4": 4,
+	}
+
+	// On an empty board every instance of a pattern is in configuration 0.
+	evaluate := func(bias float64, name string) int {
+		weights := engine.PatternWeights{Phases: 1, Bias: []float64{bias}, Tables: map[string][]map[int]float64{}}
+		if name != "" {
+			weights.Tables[name] = []map[int]float64{{0: 1}}
+		}
+
+		evaluator, err := engine.NewPatternEvaluator(weights)
+		if err != nil {
+			t.Fatalf("Expected an evaluator, instead got %s", err.Error())
+		}
+		return evaluator.Evaluate(engine.Position{})
+	}
+
+	disc := evaluate(1, "")
+	for name, instances := range expected {
+		if found := evaluate(0, name) / disc; found != instances {
+			t.Errorf("Expected %s to be found %d times, instead %d", name, instances, found)
+		}
+	}
+}
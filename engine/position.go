@@ -0,0 +1,189 @@
+package engine
+
+import (
+	"math/bits"
+	"reversi/core"
+)
+
+// Position is a bitboard copy of a core.GameState seen from the side to
+// move. Bit y*8+x is set when the cell at (x, y) is owned. Hash is the same
+// Zobrist hash core keeps for the GameState.
+type Position struct {
+	Player   uint64
+	Opponent uint64
+	Side     core.Player
+	Hash     uint64
+}
+
+const (
+	notFirstColumn = 0xfefefefefefefefe
+	notLastColumn  = 0x7f7f7f7f7f7f7f7f
+)
+
+type shifter func(uint64) uint64
+
+var directions = []shifter{
+	func(b uint64) uint64 { return (b << 1) & notFirstColumn },
+	func(b uint64) uint64 { return (b >> 1) & notLastColumn },
+	func(b uint64) uint64 { return b << 8 },
+	func(b uint64) uint64 { return b >> 8 },
+	func(b uint64) uint64 { return (b << 9) & notFirstColumn },
+	func(b uint64) uint64 { return (b << 7) & notLastColumn },
+	func(b uint64) uint64 { return (b >> 7) & notFirstColumn },
+	func(b uint64) uint64 { return (b >> 9) & notLastColumn },
+}
+
+func indexOf(coordinate core.Coordinate) int {
+	return coordinate.Y*8 + coordinate.X
+}
+
+func coordinateOf(index int) core.Coordinate {
+	return core.Coordinate{X: index % 8, Y: index / 8}
+}
+
+var (
+	// discKeys holds the core keys of BLACK and then WHITE discs by index.
+	discKeys [2][64]uint64
+	// flipKeys swaps the colour of a disc in the hash.
+	flipKeys [64]uint64
+	turnKey  = core.TurnKey(core.WHITE)
+)
+
+func init() {
+	for index := 0; index < 64; index++ {
+		discKeys[0][index] = core.ZobristKey(coordinateOf(index), core.BLACK)
+		discKeys[1][index] = core.ZobristKey(coordinateOf(index), core.WHITE)
+		flipKeys[index] = discKeys[0][index] ^ discKeys[1][index]
+	}
+}
+
+func colourOf(side core.Player) int {
+	if side == core.WHITE {
+		return 1
+	}
+
+	return 0
+}
+
+func hashOf(discs uint64, side core.Player) uint64 {
+	var hash uint64
+	for discs != 0 {
+		hash ^= discKeys[colourOf(side)][bits.TrailingZeros64(discs)]
+		discs &= discs - 1
+	}
+
+	return hash
+}
+
+func NewPosition(gameState core.GameState) Position {
+	position := Position{Side: gameState.PlayerTurn}
+
+	for coordinate, owner := range gameState.Board {
+		bit := uint64(1) << uint(indexOf(coordinate))
+		if owner.OwnedBy(gameState.PlayerTurn) {
+			position.Player |= bit
+		} else if owner.OwnedBy(gameState.PlayerTurn.Opposite()) {
+			position.Opponent |= bit
+		}
+	}
+
+	position.Hash = hashOf(position.Player, position.Side) ^
+		hashOf(position.Opponent, position.Side.Opposite()) ^
+		core.TurnKey(position.Side)
+
+	return position
+}
+
+func generateMoves(player uint64, opponent uint64) uint64 {
+	empty := ^(player | opponent)
+
+	var result uint64
+	for _, shift := range directions {
+		candidates := shift(player) & opponent
+		for i := 0; i < 5; i++ {
+			candidates |= shift(candidates) & opponent
+		}
+		result |= shift(candidates) & empty
+	}
+
+	return result
+}
+
+func generateFlips(player uint64, opponent uint64, move uint64) uint64 {
+	var result uint64
+	for _, shift := range directions {
+		var line uint64
+		cursor := shift(move)
+		for cursor&opponent != 0 {
+			line |= cursor
+			cursor = shift(cursor)
+		}
+
+		if cursor&player != 0 {
+			result |= line
+		}
+	}
+
+	return result
+}
+
+func (position Position) Moves() uint64 {
+	return generateMoves(position.Player, position.Opponent)
+}
+
+func (position Position) OpponentMoves() uint64 {
+	return generateMoves(position.Opponent, position.Player)
+}
+
+func (position Position) IsOver() bool {
+	return position.Moves() == 0 && position.OpponentMoves() == 0
+}
+
+func (position Position) Empties() int {
+	return 64 - bits.OnesCount64(position.Player|position.Opponent)
+}
+
+// DiscDifference counts the discs of the side to move minus the discs of
+// its opponent.
+func (position Position) DiscDifference() int {
+	return bits.OnesCount64(position.Player) - bits.OnesCount64(position.Opponent)
+}
+
+// Play places a disc for the side to move at the given index and returns the
+// position with the opponent to move. The move is assumed to be legal.
+func (position Position) Play(index int) Position {
+	move := uint64(1) << uint(index)
+	flipped := generateFlips(position.Player, position.Opponent, move)
+
+	hash := position.Hash ^ discKeys[colourOf(position.Side)][index] ^ turnKey
+	for discs := flipped; discs != 0; discs &= discs - 1 {
+		hash ^= flipKeys[bits.TrailingZeros64(discs)]
+	}
+
+	return Position{
+		Player:   position.Opponent &^ flipped,
+		Opponent: position.Player | flipped | move,
+		Side:     position.Side.Opposite(),
+		Hash:     hash,
+	}
+}
+
+func (position Position) Pass() Position {
+	return Position{
+		Player:   position.Opponent,
+		Opponent: position.Player,
+		Side:     position.Side.Opposite(),
+		Hash:     position.Hash ^ turnKey,
+	}
+}
+
+func moveList(moves uint64) []int {
+	result := make([]int, 0, bits.OnesCount64(moves))
+	for moves != 0 {
+		index := bits.TrailingZeros64(moves)
+		result = append(result, index)
+		moves &= moves - 1
+	}
+
+	return result
+}
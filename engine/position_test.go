@@ -0,0 +1,82 @@
+package engine_test
+
+import (
+	"math/bits"
+	"reversi/core"
+	"reversi/engine"
+	"testing"
+)
+
+func replay(moves []core.Coordinate) []core.GameState {
+	states, err := core.Replay(core.StartingPosition(), moves)
+	if err != nil {
+		panic(err)
+	}
+
+	return states
+}
+
+func stateAfter(moves []core.Coordinate) core.GameState {
+	states := replay(moves)
+	return states[len(states)-1]
+}
+
+var wholeGame = []core.Coordinate{
+	{X: 2, Y: 4}, {X: 2, Y: 5}, {X: 2, Y: 6}, {X: 1, Y: 4}, {X: 0, Y: 4}, {X: 4, Y: 5},
+	{X: 5, Y: 2}, {X: 4, Y: 2}, {X: 3, Y: 2}, {X: 2, Y: 1}, {X: 3, Y: 1}, {X: 4, Y: 1},
+	{X: 3, Y: 0}, {X: 1, Y: 5}, {X: 4, Y: 6}, {X: 2, Y: 7}, {X: 3, Y: 6}, {X: 1, Y: 3},
+	{X: 0, Y: 5}, {X: 3, Y: 5}, {X: 0, Y: 2}, {X: 1, Y: 2}, {X: 2, Y: 3}, {X: 2, Y: 2},
+	{X: 3, Y: 7}, {X: 0, Y: 3}, {X: 2, Y: 0}, {X: 6, Y: 2}, {X: 7, Y: 2}, {X: 5, Y: 3},
+	{X: 6, Y: 4}, {X: 5, Y: 4}, {X: 4, Y: 7}, {X: 7, Y: 4}, {X: 7, Y: 5}, {X: 5, Y: 7},
+	{X: 7, Y: 3}, {X: 6, Y: 3}, {X: 5, Y: 6}, {X: 5, Y: 5}, {X: 6, Y: 5}, {X: 5, Y: 0},
+	{X: 5, Y: 1}, {X: 4, Y: 0}, {X: 6, Y: 0}, {X: 1, Y: 7}, {X: 1, Y: 1}, {X: 1, Y: 0},
+	{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 6}, {X: 0, Y: 7}, {X: 0, Y: 6}, {X: 7, Y: 6},
+	{X: 7, Y: 7}, {X: 6, Y: 6}, {X: 6, Y: 7}, {X: 7, Y: 1}, {X: 6, Y: 1}, {X: 7, Y: 0},
+}
+
+func Test_PositionMoves_matchTheCoreMoveOptions(t *testing.T) {
+	states := replay(wholeGame)
+
+	for i, state := range states {
+		position := engine.NewPosition(state)
+		moves := position.Moves()
+
+		if bits.OnesCount64(moves) != len(state.MoveOptions()) {
+			t.Errorf("After %d moves expected %d moves, instead got %d", i, len(state.MoveOptions()), bits.OnesCount64(moves))
+		}
+
+		for coordinate := range state.MoveOptions() {
+			if moves&(uint64(1)<<uint(coordinate.Y*8+coordinate.X)) == 0 {
+				t.Errorf("After %d moves (%d, %d) should be a move", i, coordinate.X, coordinate.Y)
+			}
+		}
+	}
+}
+
+func Test_PositionPlay_matchesTheCoreBoard(t *testing.T) {
+	states := replay(wholeGame)
+
+	for i := 0; i+1 < len(states); i++ {
+		move := wholeGame[i]
+		played := engine.NewPosition(states[i]).Play(move.Y*8 + move.X)
+		expected := engine.NewPosition(states[i+1])
+
+		if played.Side != expected.Side {
+			played = played.Pass()
+		}
+
+		if played != expected {
+			t.Fatalf("Playing move %d (%d, %d) produced a different board", i, move.X, move.Y)
+		}
+	}
+}
+
+func Test_PositionHash_matchesTheCoreHash(t *testing.T) {
+	states := replay(wholeGame)
+
+	for i, state := range states {
+		if engine.NewPosition(state).Hash != state.Hash {
+			t.Errorf("After %d moves the position hash differs from the core hash", i)
+		}
+	}
+}
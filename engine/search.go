@@ -0,0 +1,314 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"reversi/core"
+	"sort"
+	"time"
+)
+
+const (
+	defaultDepth = 6
+	infinity     = 2 * winningScore
+	passMove     = -1
+	nodesPerPoll = 1024
+)
+
+var ErrNoMoves = errors.New("the side to move has no legal moves")
+
+type Limits struct {
+	Depth int
+	Time  time.Duration
+	// Iterations bounds the playouts of the Monte Carlo player.
+	Iterations int
+}
+
+type Result struct {
+	Move               core.Coordinate
+	Score              int
+	Depth              int
+	PrincipalVariation []core.Coordinate
+	Nodes              uint64
+	Elapsed            time.Duration
+	// WinRate and Visits are reported by the Monte Carlo player, which does
+	// not score positions.
+	WinRate float64
+	Visits  uint64
+}
+
+func (result Result) NodesPerSecond() float64 {
+	if result.Elapsed <= 0 {
+		return 0
+	}
+
+	return float64(result.Nodes) / result.Elapsed.Seconds()
+}
+
+// Player is anything that can pick a move for the side to move.
+type Player interface {
+	BestMove(gameState core.GameState, limits Limits) (Result, error)
+}
+
+type Searcher struct {
+	evaluator Evaluator
+	table     *TranspositionTable
+}
+
+type search struct {
+	evaluator  Evaluator
+	table      *TranspositionTable
+	ctx        context.Context
+	deadline   time.Time
+	canAbort   bool
+	aborted    bool
+	nodes      uint64
+	previousPV []int
+}
+
+func (search *search) timeIsUp() bool {
+	if search.aborted {
+		return true
+	}
+
+	if search.canAbort && search.nodes%nodesPerPoll == 0 {
+		search.aborted = search.ctx.Err() != nil || (!search.deadline.IsZero() && time.Now().After(search.deadline))
+	}
+
+	return search.aborted
+}
+
+// order tries the move of the previous iteration's principal variation
+// first, then the best move the table remembers, then the best squares.
+func (search *search) order(moves uint64, ply int, tableMove int) []int {
+	ordered := moveList(moves)
+
+	pvMove := passMove
+	if ply < len(search.previousPV) {
+		pvMove = search.previousPV[ply]
+	}
+
+	rank := func(move int) int {
+		if move == pvMove {
+			return 2 * infinity
+		}
+		if move == tableMove {
+			return infinity
+		}
+		return squareWeights[move]
+	}
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return rank(ordered[i]) > rank(ordered[j])
+	})
+
+	return ordered
+}
+
+// probe narrows the window with what the table knows about the position, a
+// cutoff is reported when the remembered score settles it. The root is
+// always searched so there is a move to report.
+func (search *search) probe(position Position, depth int, alpha *int, beta *int, ply int) (int, int, bool) {
+	entry, found := search.table.Probe(position.Hash)
+	if !found {
+		return passMove, 0, false
+	}
+	if ply == 0 || entry.Depth < depth {
+		return entry.Move, 0, false
+	}
+
+	switch entry.Bound {
+	case EXACT_SCORE:
+		return entry.Move, entry.Score, true
+	case LOWER_BOUND:
+		if entry.Score > *alpha {
+			*alpha = entry.Score
+		}
+	case UPPER_BOUND:
+		if entry.Score < *beta {
+			*beta = entry.Score
+		}
+	}
+
+	return entry.Move, entry.Score, *alpha >= *beta
+}
+
+func boundOf(score int, alpha int, beta int) Bound {
+	if score <= alpha {
+		return UPPER_BOUND
+	}
+	if score >= beta {
+		return LOWER_BOUND
+	}
+
+	return EXACT_SCORE
+}
+
+func (search *search) negamax(position Position, depth int, alpha int, beta int, ply int, line *[]int) int {
+	search.nodes++
+	if search.timeIsUp() {
+		return 0
+	}
+
+	moves := position.Moves()
+	if moves == 0 {
+		if position.OpponentMoves() == 0 {
+			*line = nil
+			return terminalScore(position.DiscDifference())
+		}
+
+		var child []int
+		score := -search.negamax(position.Pass(), depth, -beta, -alpha, ply+1, &child)
+		*line = append([]int{passMove}, child...)
+		return score
+	}
+
+	if depth == 0 {
+		*line = nil
+		return search.evaluator.Evaluate(position)
+	}
+
+	originalAlpha, originalBeta := alpha, beta
+	tableMove, tableScore, cutoff := search.probe(position, depth, &alpha, &beta, ply)
+	if cutoff {
+		*line = nil
+		return tableScore
+	}
+
+	best := -infinity
+	bestMove := passMove
+	for _, move := range search.order(moves, ply, tableMove) {
+		var child []int
+		score := -search.negamax(position.Play(move), depth-1, -beta, -alpha, ply+1, &child)
+		if search.aborted {
+			return 0
+		}
+
+		if score > best {
+			best = score
+			bestMove = move
+			if score > alpha {
+				alpha = score
+				*line = append([]int{move}, child...)
+			}
+		}
+
+		if alpha >= beta {
+			break
+		}
+	}
+
+	search.table.Store(position.Hash, TableEntry{
+		Move:  bestMove,
+		Score: best,
+		Depth: depth,
+		Bound: boundOf(best, originalAlpha, originalBeta),
+	})
+
+	return best
+}
+
+// toCoordinates drops the passes, the core moves to the side that can play
+// on its own.
+func toCoordinates(line []int) []core.Coordinate {
+	result := []core.Coordinate{}
+	for _, move := range line {
+		if move != passMove {
+			result = append(result, coordinateOf(move))
+		}
+	}
+
+	return result
+}
+
+func maximumDepth(position Position, limits Limits) int {
+	depth := limits.Depth
+	if depth <= 0 {
+		depth = defaultDepth
+		if limits.Time > 0 {
+			depth = position.Empties()
+		}
+	}
+
+	if depth > position.Empties() {
+		depth = position.Empties()
+	}
+
+	return depth
+}
+
+func newSearch(ctx context.Context, evaluator Evaluator, table *TranspositionTable, limits Limits, started time.Time) *search {
+	search := &search{evaluator: evaluator, table: table, ctx: ctx}
+	if limits.Time > 0 {
+		search.deadline = started.Add(limits.Time)
+	}
+
+	return search
+}
+
+// deepen searches one depth after the other from firstDepth until the limits
+// are reached and reports the deepest completed search, which has Depth 0
+// when none completed.
+func (search *search) deepen(position Position, limits Limits, firstDepth int) Result {
+	result := Result{}
+	for depth := firstDepth; depth <= maximumDepth(position, limits); depth++ {
+		var line []int
+		score := search.negamax(position, depth, -infinity, infinity, 0, &line)
+		if search.aborted {
+			break
+		}
+
+		result = Result{
+			Move:               coordinateOf(line[0]),
+			Score:              score,
+			Depth:              depth,
+			PrincipalVariation: toCoordinates(line),
+		}
+		search.previousPV = line
+		search.canAbort = true
+	}
+
+	return result
+}
+
+func (searcher *Searcher) searchPosition(ctx context.Context, position Position, limits Limits) (Result, error) {
+	if position.Moves() == 0 {
+		return Result{}, ErrNoMoves
+	}
+
+	started := time.Now()
+	searcher.table.NewSearch()
+	search := newSearch(ctx, searcher.evaluator, searcher.table, limits, started)
+
+	result := search.deepen(position, limits, 1)
+	result.Nodes = search.nodes
+	result.Elapsed = time.Since(started)
+
+	return result, nil
+}
+
+// BestMove searches the position with iterative deepening until the depth or
+// the time limit is reached and reports the deepest completed search.
+func (searcher *Searcher) BestMove(gameState core.GameState, limits Limits) (Result, error) {
+	return searcher.searchPosition(context.Background(), NewPosition(gameState), limits)
+}
+
+// Search is BestMove that also stops when the context is done. The first
+// depth is always completed so there is a move to report.
+func (searcher *Searcher) Search(ctx context.Context, gameState core.GameState, limits Limits) (Result, error) {
+	return searcher.searchPosition(ctx, NewPosition(gameState), limits)
+}
+
+func NewSearcher(evaluator Evaluator) *Searcher {
+	return NewSearcherWithTable(evaluator, NewTranspositionTable(defaultTableMegabytes))
+}
+
+// NewSearcherWithTable lets several searchers share what they have learned
+// about positions.
+func NewSearcherWithTable(evaluator Evaluator, table *TranspositionTable) *Searcher {
+	return &Searcher{evaluator: evaluator, table: table}
+}
+
+func BestMove(gameState core.GameState, limits Limits) (Result, error) {
+	return NewSearcher(NewWeightedSquareEvaluator()).BestMove(gameState, limits)
+}
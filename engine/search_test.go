@@ -0,0 +1,79 @@
+package engine_test
+
+import (
+	"reversi/core"
+	"reversi/engine"
+	"testing"
+	"time"
+)
+
+var wipeout = []core.Coordinate{
+	{X: 2, Y: 4}, {X: 2, Y: 3}, {X: 1, Y: 2}, {X: 1, Y: 5}, {X: 1, Y: 4},
+	{X: 2, Y: 5}, {X: 4, Y: 2}, {X: 1, Y: 3}, {X: 1, Y: 6},
+}
+
+func Test_BestMove_findsTheWinningMove(t *testing.T) {
+	state := stateAfter(wipeout[:8])
+
+	result, err := engine.BestMove(state, engine.Limits{Depth: 3})
+	if err != nil {
+		t.Fatalf("Expected a move, instead got %s", err.Error())
+	}
+
+	if result.Move != (core.Coordinate{X: 1, Y: 6}) {
+		t.Errorf("Expected (1, 6) to win the game, instead got (%d, %d)", result.Move.X, result.Move.Y)
+	}
+
+	margin, exact := engine.FinalMargin(result.Score)
+	if !exact || margin != 13 {
+		t.Errorf("Expected an exact win by 13, instead got %d (exact: %t)", margin, exact)
+	}
+}
+
+func Test_BestMove_principalVariationIsPlayable(t *testing.T) {
+	state := stateAfter(wholeGame[:20])
+
+	result, err := engine.BestMove(state, engine.Limits{Depth: 5})
+	if err != nil {
+		t.Fatalf("Expected a move, instead got %s", err.Error())
+	}
+
+	if len(result.PrincipalVariation) == 0 || result.PrincipalVariation[0] != result.Move {
+		t.Fatalf("The principal variation should start with the best move: %v", result.PrincipalVariation)
+	}
+
+	moves := append(append([]core.Coordinate{}, wholeGame[:20]...), result.PrincipalVariation...)
+	states := replay(moves)
+	for i := 20; i < len(moves); i++ {
+		if !states[i].MoveOptions()[moves[i]] {
+			t.Fatalf("Move %d of the principal variation is not legal", i-20)
+		}
+	}
+}
+
+func Test_BestMove_respectsTheTimeLimit(t *testing.T) {
+	state := stateAfter([]core.Coordinate{})
+
+	started := time.Now()
+	result, err := engine.BestMove(state, engine.Limits{Time: 100 * time.Millisecond})
+	elapsed := time.Since(started)
+
+	if err != nil {
+		t.Fatalf("Expected a move, instead got %s", err.Error())
+	}
+	if elapsed > time.Second {
+		t.Errorf("The search should stop close to the time limit, instead took %s", elapsed)
+	}
+	if result.Depth < 1 || !state.MoveOptions()[result.Move] {
+		t.Errorf("Expected a legal move from a completed search, instead got (%d, %d) at depth %d", result.Move.X, result.Move.Y, result.Depth)
+	}
+}
+
+func Test_BestMove_whenTheGameIsOver_returnsAnError(t *testing.T) {
+	state := stateAfter(wipeout)
+
+	_, err := engine.BestMove(state, engine.Limits{Depth: 3})
+	if err != engine.ErrNoMoves {
+		t.Errorf("Expected ErrNoMoves, instead got %v", err)
+	}
+}
@@ -0,0 +1,145 @@
+package engine
+
+import (
+	"errors"
+	"math/bits"
+	"math/rand"
+	"reversi/core"
+	"strings"
+	"time"
+)
+
+type Strategy string
+
+const (
+	RANDOM  Strategy = "RANDOM"
+	GREEDY  Strategy = "GREEDY"
+	CORNERS Strategy = "CORNERS"
+	SEARCH  Strategy = "SEARCH"
+	MCTS    Strategy = "MCTS"
+)
+
+var Strategies = []Strategy{RANDOM, GREEDY, CORNERS, SEARCH, MCTS}
+
+var ErrUnknownStrategy = errors.New("unknown strategy")
+
+// cornerNeighbours are the squares next to each corner, giving them away
+// usually gives away the corner.
+var cornerNeighbours = map[int]uint64{
+	0:  0x0000000000000302,
+	7:  0x000000000000c040,
+	56: 0x0203000000000000,
+	63: 0x40c0000000000000,
+}
+
+// simplePlayer picks among the legal moves with a rule instead of searching.
+type simplePlayer struct {
+	random *rand.Rand
+	choose func(position Position, moves uint64, random *rand.Rand) int
+}
+
+func (player *simplePlayer) BestMove(gameState core.GameState, limits Limits) (Result, error) {
+	started := time.Now()
+
+	position := NewPosition(gameState)
+	moves := position.Moves()
+	if moves == 0 {
+		return Result{}, ErrNoMoves
+	}
+
+	move := player.choose(position, moves, player.random)
+
+	return Result{
+		Move:               coordinateOf(move),
+		Depth:              1,
+		PrincipalVariation: []core.Coordinate{coordinateOf(move)},
+		Nodes:              uint64(bits.OnesCount64(moves)),
+		Elapsed:            time.Since(started),
+	}, nil
+}
+
+func chooseRandom(position Position, moves uint64, random *rand.Rand) int {
+	return randomBit(moves, random)
+}
+
+// chooseGreedy flips as many discs as it can.
+func chooseGreedy(position Position, moves uint64, random *rand.Rand) int {
+	var best uint64
+	mostFlips := -1
+	for _, move := range moveList(moves) {
+		flips := bits.OnesCount64(generateFlips(position.Player, position.Opponent, uint64(1)<<uint(move)))
+		if flips > mostFlips {
+			best = 0
+			mostFlips = flips
+		}
+		if flips == mostFlips {
+			best |= uint64(1) << uint(move)
+		}
+	}
+
+	return randomBit(best, random)
+}
+
+// chooseCorners takes a corner when it can and otherwise stays away from the
+// squares next to corners that are still empty.
+func chooseCorners(position Position, moves uint64, random *rand.Rand) int {
+	if moves&corners != 0 {
+		return randomBit(moves&corners, random)
+	}
+
+	empty := ^(position.Player | position.Opponent)
+	var risky uint64
+	for corner, neighbours := range cornerNeighbours {
+		if empty&(uint64(1)<<uint(corner)) != 0 {
+			risky |= neighbours
+		}
+	}
+
+	if moves&^risky != 0 {
+		return randomBit(moves&^risky, random)
+	}
+
+	return randomBit(moves, random)
+}
+
+func newSimplePlayer(seed int64, choose func(Position, uint64, *rand.Rand) int) Player {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	return &simplePlayer{
+		random: rand.New(rand.NewSource(seed)),
+		choose: choose,
+	}
+}
+
+// ParseStrategy accepts the strategy names in any case.
+func ParseStrategy(name string) (Strategy, error) {
+	strategy := Strategy(strings.ToUpper(strings.TrimSpace(name)))
+	for _, known := range Strategies {
+		if strategy == known {
+			return strategy, nil
+		}
+	}
+
+	return "", ErrUnknownStrategy
+}
+
+// NewPlayer makes a player for the strategy, the seed makes the choices of
+// the random ones repeatable and 0 picks one from the clock.
+func NewPlayer(strategy Strategy, seed int64) (Player, error) {
+	switch strategy {
+	case RANDOM:
+		return newSimplePlayer(seed, chooseRandom), nil
+	case GREEDY:
+		return newSimplePlayer(seed, chooseGreedy), nil
+	case CORNERS:
+		return newSimplePlayer(seed, chooseCorners), nil
+	case SEARCH:
+		return NewSearcher(NewWeightedSquareEvaluator()), nil
+	case MCTS:
+		return NewMCTSPlayer(MCTSOptions{Playouts: GUIDED_PLAYOUTS, Seed: seed}), nil
+	}
+
+	return nil, ErrUnknownStrategy
+}
@@ -0,0 +1,75 @@
+package engine_test
+
+import (
+	"reversi/core"
+	"reversi/engine"
+	"testing"
+)
+
+func Test_EveryStrategy_playsLegalMovesUntilTheEnd(t *testing.T) {
+	for _, strategy := range engine.Strategies {
+		player, err := engine.NewPlayer(strategy, 1)
+		if err != nil {
+			t.Fatalf("Expected a %s player, instead got %s", strategy, err.Error())
+		}
+
+		moves := []core.Coordinate{}
+		state := stateAfter(moves)
+		for !state.IsOver() {
+			result, err := player.BestMove(state, engine.Limits{Depth: 2, Iterations: 200})
+			if err != nil {
+				t.Fatalf("%s failed to pick a move: %s", strategy, err.Error())
+			}
+			if !state.MoveOptions()[result.Move] {
+				t.Fatalf("%s picked the illegal move (%d, %d)", strategy, result.Move.X, result.Move.Y)
+			}
+
+			moves = append(moves, result.Move)
+			state = stateAfter(moves)
+		}
+	}
+}
+
+func Test_GreedyStrategy_flipsTheMostDiscs(t *testing.T) {
+	player, _ := engine.NewPlayer(engine.GREEDY, 1)
+
+	for _, played := range []int{10, 20, 30} {
+		state := stateAfter(wholeGame[:played])
+
+		mostDiscs := 0
+		for move := range state.MoveOptions() {
+			discs := stateAfter(append(append([]core.Coordinate{}, wholeGame[:played]...), move)).DiscCount(state.PlayerTurn)
+			if discs > mostDiscs {
+				mostDiscs = discs
+			}
+		}
+
+		result, _ := player.BestMove(state, engine.Limits{})
+		discs := stateAfter(append(append([]core.Coordinate{}, wholeGame[:played]...), result.Move)).DiscCount(state.PlayerTurn)
+		if discs != mostDiscs {
+			t.Errorf("After %d moves the greedy move should end with %d discs, instead %d", played, mostDiscs, discs)
+		}
+	}
+}
+
+func Test_CornerStrategy_takesTheCorner(t *testing.T) {
+	player, _ := engine.NewPlayer(engine.CORNERS, 1)
+	state := stateAfter(wholeGame[:48])
+
+	result, _ := player.BestMove(state, engine.Limits{})
+	if result.Move != (core.Coordinate{X: 0, Y: 0}) {
+		t.Errorf("Expected the corner (0, 0), instead got (%d, %d)", result.Move.X, result.Move.Y)
+	}
+}
+
+func Test_ParseStrategy_ignoresCase(t *testing.T) {
+	strategy, err := engine.ParseStrategy(" greedy ")
+	if err != nil || strategy != engine.GREEDY {
+		t.Errorf("Expected GREEDY, instead got %s (%v)", strategy, err)
+	}
+
+	_, err = engine.ParseStrategy("psychic")
+	if err != engine.ErrUnknownStrategy {
+		t.Errorf("Expected ErrUnknownStrategy, instead got %v", err)
+	}
+}
@@ -0,0 +1,135 @@
+package engine
+
+import "sync/atomic"
+
+type Bound uint8
+
+const (
+	EXACT_SCORE Bound = iota
+	// LOWER_BOUND scores failed high, the position is worth at least that.
+	LOWER_BOUND
+	// UPPER_BOUND scores failed low, the position is worth at most that.
+	UPPER_BOUND
+)
+
+const (
+	defaultTableMegabytes = 8
+	slotSize              = 16
+	minimumSlots          = 1024
+)
+
+type TableEntry struct {
+	// Move is the best move found, passMove when there was none.
+	Move  int
+	Score int
+	Depth int
+	Bound Bound
+}
+
+// The entry is packed into one word so a slot can be read and written with
+// two atomic operations. The key is stored xored with the data, a slot torn
+// by two searches writing at once no longer matches either hash.
+const (
+	moveShift       = 32
+	depthShift      = 40
+	boundShift      = 48
+	generationShift = 56
+	generationMask  = 0x7f
+	usedBit         = uint64(1) << 63
+)
+
+type slot struct {
+	key  uint64
+	data uint64
+}
+
+// TranspositionTable remembers search results by Zobrist hash in a fixed
+// number of slots. A slot is replaced by a result from a newer search, or
+// from the same search when the new result looked at least as deep. It is
+// safe to share between searches running at the same time.
+type TranspositionTable struct {
+	slots      []slot
+	mask       uint64
+	generation uint64
+}
+
+func pack(entry TableEntry, generation uint64) uint64 {
+	return uint64(uint32(int32(entry.Score))) |
+		uint64(entry.Move+1)<<moveShift |
+		uint64(uint8(entry.Depth))<<depthShift |
+		uint64(entry.Bound)<<boundShift |
+		(generation&generationMask)<<generationShift |
+		usedBit
+}
+
+func unpack(data uint64) TableEntry {
+	return TableEntry{
+		Score: int(int32(uint32(data))),
+		Move:  int(uint8(data>>moveShift)) - 1,
+		Depth: int(uint8(data >> depthShift)),
+		Bound: Bound(uint8(data>>boundShift) & 3),
+	}
+}
+
+func generationOf(data uint64) uint64 {
+	return (data >> generationShift) & generationMask
+}
+
+func (table *TranspositionTable) slotFor(hash uint64) *slot {
+	return &table.slots[hash&table.mask]
+}
+
+func (table *TranspositionTable) Probe(hash uint64) (TableEntry, bool) {
+	slot := table.slotFor(hash)
+	data := atomic.LoadUint64(&slot.data)
+	key := atomic.LoadUint64(&slot.key)
+
+	if data&usedBit == 0 || key^data != hash {
+		return TableEntry{}, false
+	}
+
+	return unpack(data), true
+}
+
+func (table *TranspositionTable) Store(hash uint64, entry TableEntry) {
+	slot := table.slotFor(hash)
+	generation := atomic.LoadUint64(&table.generation)
+
+	old := atomic.LoadUint64(&slot.data)
+	if old&usedBit != 0 && generationOf(old) == generation&generationMask {
+		oldHash := atomic.LoadUint64(&slot.key) ^ old
+		if oldHash != hash && unpack(old).Depth > entry.Depth {
+			return
+		}
+	}
+
+	data := pack(entry, generation)
+	atomic.StoreUint64(&slot.data, data)
+	atomic.StoreUint64(&slot.key, hash^data)
+}
+
+// NewSearch ages the entries already in the table, they are replaced first.
+func (table *TranspositionTable) NewSearch() {
+	atomic.AddUint64(&table.generation, 1)
+}
+
+func (table *TranspositionTable) Clear() {
+	for i := range table.slots {
+		atomic.StoreUint64(&table.slots[i].data, 0)
+		atomic.StoreUint64(&table.slots[i].key, 0)
+	}
+}
+
+// NewTranspositionTable uses at most the given number of megabytes, rounded
+// down to a power of two slots.
+func NewTranspositionTable(megabytes int) *TranspositionTable {
+	slots := uint64(minimumSlots)
+	for slots*2*slotSize <= uint64(megabytes)<<20 {
+		slots *= 2
+	}
+
+	return &TranspositionTable{
+		slots: make([]slot, slots),
+		mask:  slots - 1,
+	}
+}
@@ -0,0 +1,72 @@
+package engine_test
+
+import (
+	"reversi/engine"
+	"testing"
+)
+
+func Test_TranspositionTable_returnsWhatWasStored(t *testing.T) {
+	table := engine.NewTranspositionTable(1)
+
+	entries := []engine.TableEntry{
+		{Move: 19, Score: 1000000 + 1300, Depth: 12, Bound: engine.EXACT_SCORE},
+		{Move: -1, Score: -1000000 - 6400, Depth: 0, Bound: engine.UPPER_BOUND},
+		{Move: 63, Score: -35, Depth: 64, Bound: engine.LOWER_BOUND},
+	}
+
+	for i, entry := range entries {
+		hash := uint64(0x9e3779b97f4a7c15) * uint64(i+1)
+		table.Store(hash, entry)
+
+		found, ok := table.Probe(hash)
+		if !ok || found != entry {
+			t.Errorf("Expected %+v, instead got %+v (found: %t)", entry, found, ok)
+		}
+	}
+
+	if _, ok := table.Probe(12345); ok {
+		t.Error("A hash that was never stored should not be found")
+	}
+}
+
+func Test_TranspositionTable_keepsTheDeeperResultOfASearch(t *testing.T) {
+	table := engine.NewTranspositionTable(0)
+	deep := engine.TableEntry{Move: 1, Score: 10, Depth: 8}
+	shallow := engine.TableEntry{Move: 2, Score: 20, Depth: 3}
+
+	// Both hashes land in the same slot of the smallest table.
+	table.Store(7, deep)
+	table.Store(7+1<<40, shallow)
+
+	if _, ok := table.Probe(7 + 1<<40); ok {
+		t.Error("The shallower result should not replace the deeper one")
+	}
+	if found, _ := table.Probe(7); found != deep {
+		t.Errorf("Expected the deeper result to be kept, instead got %+v", found)
+	}
+
+	table.NewSearch()
+	table.Store(7+1<<40, shallow)
+
+	if found, ok := table.Probe(7 + 1<<40); !ok || found != shallow {
+		t.Error("Results of an older search should be replaced")
+	}
+	if _, ok := table.Probe(7); ok {
+		t.Error("The replaced result should no longer be found")
+	}
+}
+
+func Test_SharedTable_savesWorkOnTheNextSearch(t *testing.T) {
+	state := stateAfter(wholeGame[:20])
+	table := engine.NewTranspositionTable(4)
+
+	first, _ := engine.NewSearcherWithTable(engine.NewWeightedSquareEvaluator(), table).BestMove(state, engine.Limits{Depth: 6})
+	second, _ := engine.NewSearcherWithTable(engine.NewWeightedSquareEvaluator(), table).BestMove(state, engine.Limits{Depth: 6})
+
+	if second.Nodes >= first.Nodes {
+		t.Errorf("The second search should reuse the table, it took %d nodes against %d", second.Nodes, first.Nodes)
+	}
+	if second.Score != first.Score {
+		t.Errorf("Both searches should agree on the score, instead got %d and %d", first.Score, second.Score)
+	}
+}
@@ -0,0 +1,199 @@
+package ggf
+
+import (
+	"errors"
+	"fmt"
+	"reversi/core"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	passMove   = "PA"
+	othello    = "Othello"
+	boardSize  = "8"
+	resigned   = "r"
+	timedOut   = "t"
+	dateLayout = "2006.01.02_15:04:05.MST"
+)
+
+var (
+	ErrNotGGF          = errors.New("not a GGF game")
+	ErrUnsupportedGame = errors.New("not a game of 8x8 Othello")
+	ErrIllegalMove     = core.ErrIllegalMove
+)
+
+// Move is one move of a game, or a pass. Evaluation is the score in discs the
+// player gave it and Time how long they took, both left out when not known.
+type Move struct {
+	Side          core.Player
+	Square        core.Coordinate
+	Pass          bool
+	Evaluation    float64
+	HasEvaluation bool
+	Time          time.Duration
+}
+
+// Game is a game in the Generic Game Format of the online Othello servers.
+// Result is from black's point of view, such as +12, -4 or 0, with :r for a
+// resignation or :t for a loss on time, and empty while the game goes on.
+type Game struct {
+	Place       string
+	Date        string
+	Black       string
+	White       string
+	BlackRating float64
+	WhiteRating float64
+	TimeControl string
+	Result      string
+	Start       core.GameState
+	Moves       []Move
+}
+
+func colourTag(side core.Player) string {
+	if side == core.WHITE {
+		return "W"
+	}
+
+	return "B"
+}
+
+// boardText is the GGF board: its size, the squares row by row from A1 and
+// the side to move, * for black and O for white.
+func boardText(gameState core.GameState) string {
+	encoded := gameState.Encode()
+	board := strings.NewReplacer("X", "*").Replace(encoded[:64])
+
+	side := "*"
+	if gameState.PlayerTurn == core.WHITE {
+		side = "O"
+	}
+
+	return boardSize + " " + board + " " + side
+}
+
+func formatSeconds(duration time.Duration) string {
+	return strconv.FormatFloat(duration.Seconds(), 'f', -1, 64)
+}
+
+func (move Move) text() string {
+	square := passMove
+	if !move.Pass {
+		square = strings.ToUpper(move.Square.String())
+	}
+
+	evaluation := ""
+	if move.HasEvaluation {
+		evaluation = strconv.FormatFloat(move.Evaluation, 'f', 2, 64)
+	}
+
+	switch {
+	case move.Time > 0:
+		return square + "/" + evaluation + "/" + formatSeconds(move.Time)
+	case move.HasEvaluation:
+		return square + "/" + evaluation
+	}
+
+	return square
+}
+
+func writeProperty(text *strings.Builder, tag string, value string) {
+	if value != "" {
+		text.WriteString(tag + "[" + value + "]")
+	}
+}
+
+func formatRating(rating float64) string {
+	if rating == 0 {
+		return ""
+	}
+
+	return strconv.FormatFloat(rating, 'f', 0, 64)
+}
+
+// String writes the game on one line, as GGF files keep them.
+func (game Game) String() string {
+	var text strings.Builder
+	text.WriteString("(;GM[" + othello + "]")
+	writeProperty(&text, "PC", game.Place)
+	writeProperty(&text, "DT", game.Date)
+	writeProperty(&text, "PB", game.Black)
+	writeProperty(&text, "PW", game.White)
+	writeProperty(&text, "RB", formatRating(game.BlackRating))
+	writeProperty(&text, "RW", formatRating(game.WhiteRating))
+	writeProperty(&text, "TI", game.TimeControl)
+	text.WriteString("TY[" + boardSize + "]")
+	writeProperty(&text, "RE", game.Result)
+	text.WriteString("BO[" + boardText(game.Start) + "]")
+
+	for _, move := range game.Moves {
+		text.WriteString(colourTag(move.Side) + "[" + move.text() + "]")
+	}
+
+	text.WriteString(";)")
+	return text.String()
+}
+
+// squaresOf leaves the passes out of the moves.
+func squaresOf(moves []Move) []core.Coordinate {
+	squares := []core.Coordinate{}
+	for _, move := range moves {
+		if !move.Pass {
+			squares = append(squares, move.Square)
+		}
+	}
+
+	return squares
+}
+
+// Replay feeds the game through the core from its starting board and returns
+// the position before the first move and after each one, passes left out. A
+// pass is only accepted from a side without a move.
+func (game Game) Replay() ([]core.GameState, error) {
+	states, err := core.Replay(game.Start, squaresOf(game.Moves))
+	if err != nil {
+		return nil, err
+	}
+
+	played := 0
+	for i, move := range game.Moves {
+		state := states[played]
+
+		if move.Pass {
+			if state.IsOver() || state.PlayerTurn == move.Side {
+				return nil, fmt.Errorf("%w: move %d, %s passed with a move to play", ErrIllegalMove, i+1, move.Side)
+			}
+			continue
+		}
+
+		if state.PlayerTurn != move.Side {
+			return nil, fmt.Errorf("%w: move %d, %s by %s", ErrIllegalMove, i+1, move.Square, move.Side)
+		}
+		played++
+	}
+
+	return states, nil
+}
+
+// MovesFrom writes out the moves played from the position, with the passes
+// the core makes on its own.
+func MovesFrom(start core.GameState, squares []core.Coordinate) ([]Move, error) {
+	states, err := core.Replay(start, squares)
+	if err != nil {
+		return nil, err
+	}
+
+	moves := []Move{}
+	toMove := states[0].PlayerTurn
+	for i, square := range squares {
+		side := states[i].PlayerTurn
+		if side != toMove {
+			moves = append(moves, Move{Side: toMove, Pass: true})
+		}
+		moves = append(moves, Move{Side: side, Square: square})
+		toMove = side.Opposite()
+	}
+
+	return moves, nil
+}
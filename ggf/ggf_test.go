@@ -0,0 +1,205 @@
+package ggf_test
+
+import (
+	"errors"
+	"reversi/core"
+	"reversi/ggf"
+	"reversi/records"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	wholeGame = "c5 c6 c7 b5 a5 e6 f3 e3 d3 c2 d2 e2 d1 b6 e7 c8 d7 b4 a6 d6 a3 b3 c4 c3 " +
+		"d8 a4 c1 g3 h3 f4 g5 f5 e8 h5 h6 f8 h4 g4 f7 f6 g6 f1 f2 e1 g1 b8 b2 b1 a1 a2 b7 a8 a7 h7 h8 g7 g8 h2 g2 h1"
+	// onePass is won by black with every disc, after white has passed once.
+	onePass = "f4 f3 f2 c4 c6 e6 e7 g2 b4 f5 f6 b3 h1 b7 a2 a8"
+	// standardBoard is the usual board of other servers, mirrored from ours.
+	standardBoard = "8 ---------------------------O*------*O--------------------------- *"
+)
+
+func movesOf(t *testing.T, transcript string) []core.Coordinate {
+	moves, err := core.ParseMoves(transcript)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %s", transcript, err.Error())
+	}
+
+	return moves
+}
+
+func gameOf(t *testing.T, transcript string) ggf.Game {
+	moves, err := ggf.MovesFrom(core.StartingPosition(), movesOf(t, transcript))
+	if err != nil {
+		t.Fatalf("Failed to write out %s: %s", transcript, err.Error())
+	}
+
+	return ggf.Game{Black: "alice", White: "bob", Start: core.StartingPosition(), Moves: moves}
+}
+
+func Test_WrittenGame_isReadBack(t *testing.T) {
+	game := gameOf(t, wholeGame)
+	game.Place, game.Date, game.TimeControl, game.Result = "reversi", "2026.10.19_12:00:00.UTC", "05:00/00:03", "+8"
+	game.BlackRating, game.WhiteRating = 1620, 1480
+	game.Moves[0].Evaluation, game.Moves[0].HasEvaluation = -1.5, true
+	game.Moves[1].Time = 2500 * time.Millisecond
+
+	parsed, err := ggf.Parse(game.String())
+	if err != nil {
+		t.Fatalf("Failed to read %s back: %s", game.String(), err.Error())
+	}
+
+	if parsed.String() != game.String() {
+		t.Errorf("Expected %s, instead got %s", game.String(), parsed.String())
+	}
+	if !parsed.Moves[0].HasEvaluation || parsed.Moves[0].Evaluation != -1.5 || parsed.Moves[1].Time != game.Moves[1].Time {
+		t.Errorf("Expected the evaluation and the time to be read back, instead got %+v and %+v", parsed.Moves[0], parsed.Moves[1])
+	}
+}
+
+func Test_MovesFrom_writesOutThePasses(t *testing.T) {
+	game := gameOf(t, onePass)
+
+	passes := 0
+	for _, move := range game.Moves {
+		if move.Pass {
+			passes++
+		}
+	}
+	if passes != 1 || !strings.Contains(game.String(), "W[PA]") {
+		t.Fatalf("Expected white to pass once, instead got %s", game.String())
+	}
+
+	states, err := game.Replay()
+	if err != nil || !states[len(states)-1].IsOver() {
+		t.Errorf("Expected the game to be replayed to its end, instead got %v", err)
+	}
+}
+
+func Test_Replay_playsFromTheStandardBoard(t *testing.T) {
+	game, err := ggf.Parse("(;GM[Othello]PC[GGS/os]PB[alice]PW[bob]TY[8]BO[" + standardBoard + "]B[F5//1.2]W[d6/-0.50]B[C3];)")
+	if err != nil {
+		t.Fatalf("Failed to read the game: %s", err.Error())
+	}
+
+	states, err := game.Replay()
+	if err != nil || len(states) != 4 {
+		t.Fatalf("Expected the 3 moves to be played, instead got %v", err)
+	}
+	if final := states[3]; final.DiscCount(core.BLACK) != 5 || final.DiscCount(core.WHITE) != 2 || final.PlayerTurn != core.WHITE {
+		t.Errorf("Expected 5 black discs, 2 white and white to move, instead got %s", final.Encode())
+	}
+}
+
+func Test_Replay_refusesIllegalMovesAndPasses(t *testing.T) {
+	for _, moves := range []string{"B[A1]", "W[F5]", "B[PA]", "B[F5]B[D6]"} {
+		game, err := ggf.Parse("(;GM[Othello]TY[8]BO[" + standardBoard + "]" + moves + ";)")
+		if err != nil {
+			t.Fatalf("Failed to read %s: %s", moves, err.Error())
+		}
+
+		if _, err := game.Replay(); !errors.Is(err, ggf.ErrIllegalMove) {
+			t.Errorf("Expected %s to be refused, instead got %v", moves, err)
+		}
+	}
+}
+
+func Test_Parse_refusesOtherGames(t *testing.T) {
+	for _, text := range []string{
+		"(;GM[Chess]TY[8]BO[" + standardBoard + "];)",
+		"(;GM[Othello]TY[10]BO[" + standardBoard + "];)",
+		"(;GM[Othello]TY[8]BO[10 " + strings.Repeat("-", 100) + " *];)",
+	} {
+		if _, err := ggf.Parse(text); !errors.Is(err, ggf.ErrUnsupportedGame) {
+			t.Errorf("Expected %s to be refused, instead got %v", text, err)
+		}
+	}
+
+	if _, err := ggf.Parse("GM[Othello]"); !errors.Is(err, ggf.ErrNotGGF) {
+		t.Errorf("Expected a game without (; to be refused, instead got %v", err)
+	}
+}
+
+func Test_ParseAll_readsEveryGame(t *testing.T) {
+	text := gameOf(t, wholeGame).String() + "\n" + gameOf(t, onePass).String() + "\n"
+
+	games, err := ggf.ParseAll(text)
+	if err != nil || len(games) != 2 {
+		t.Fatalf("Expected 2 games, instead got %d (%v)", len(games), err)
+	}
+}
+
+func Test_ForfeitedRecord_isWrittenAsAResignationAndReadBack(t *testing.T) {
+	started := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	record := records.GameRecord{
+		GameId:      "game",
+		Black:       "alice",
+		White:       "bob",
+		Moves:       movesOf(t, wholeGame)[:20],
+		Winner:      core.WHITE,
+		Forfeit:     core.BLACK,
+		TimeControl: "5+3",
+		StartedAt:   started,
+	}
+
+	game, err := ggf.FromRecord(record, 1620, 0)
+	if err != nil {
+		t.Fatalf("Failed to write the record: %s", err.Error())
+	}
+	if game.Result != "-64:r" || game.TimeControl != "05:00/00:03" || !strings.Contains(game.String(), "RB[1620]") {
+		t.Errorf("Expected a resignation by black, a clock of 05:00/00:03 and a rating, instead got %s", game.String())
+	}
+
+	parsed, err := ggf.Parse(game.String())
+	if err != nil {
+		t.Fatalf("Failed to read %s back: %s", game.String(), err.Error())
+	}
+	readBack, err := parsed.Record("copy")
+	if err != nil {
+		t.Fatalf("Failed to turn %s into a record: %s", game.String(), err.Error())
+	}
+
+	if readBack.Winner != core.WHITE || readBack.Forfeit != core.BLACK || readBack.TimeControl != "5+3" ||
+		!readBack.StartedAt.Equal(started) || core.FormatMoves(readBack.Moves) != core.FormatMoves(record.Moves) {
+		t.Errorf("Expected the record back, instead got %+v", readBack)
+	}
+}
+
+func Test_UnfinishedGame_isWrittenButNotRecorded(t *testing.T) {
+	game := gameOf(t, onePass)
+	game.Moves = game.Moves[:10]
+
+	parsed, err := ggf.Parse(game.String())
+	if err != nil || parsed.Result != "" || len(parsed.Moves) != 10 {
+		t.Fatalf("Expected the unfinished game to be read back, instead got %s (%v)", parsed.String(), err)
+	}
+
+	if _, err := parsed.Record("unfinished"); !errors.Is(err, ggf.ErrUnfinished) {
+		t.Errorf("Expected an unfinished game not to be recorded, instead got %v", err)
+	}
+}
+
+func Test_GameFromTheStandardBoard_isRecordedMirrored(t *testing.T) {
+	game, err := ggf.Parse("(;GM[Othello]PB[alice]PW[bob]TY[8]RE[+64]BO[" + standardBoard + "]" +
+		"B[F5]W[F6]B[F7]W[C5]B[C3]W[E3]B[E2]W[G7]B[B5]W[F4]B[F3]W[B6]B[H8]W[B2]B[A7]W[PA]B[A1];)")
+	if err != nil {
+		t.Fatalf("Failed to read the game: %s", err.Error())
+	}
+
+	record, err := game.Record("mirrored")
+	if err != nil {
+		t.Fatalf("Failed to record the game: %s", err.Error())
+	}
+	if core.FormatMoves(record.Moves) != onePass || record.Winner != core.BLACK || record.BlackDiscs != 20 {
+		t.Errorf("Expected %s won by black, instead got %s won by %s", onePass, core.FormatMoves(record.Moves), record.Winner)
+	}
+}
+
+func Test_Replay_refusesAGameWithoutAStartingBoard(t *testing.T) {
+	game := gameOf(t, onePass)
+	game.Start = core.GameState{}
+
+	if _, err := game.Replay(); !errors.Is(err, core.ErrBadPosition) {
+		t.Errorf("Expected a game without a board to be refused, instead got %v", err)
+	}
+}
@@ -0,0 +1,216 @@
+package ggf
+
+import (
+	"fmt"
+	"reversi/core"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type property struct {
+	tag   string
+	value string
+}
+
+func isTag(tag string) bool {
+	for _, letter := range tag {
+		if letter < 'A' || letter > 'Z' {
+			return false
+		}
+	}
+
+	return tag != ""
+}
+
+// propertiesOf reads the TAG[value] pairs between the (; and ;) of a game.
+func propertiesOf(body string) ([]property, error) {
+	properties := []property{}
+	for {
+		body = strings.TrimSpace(body)
+		if body == "" {
+			return properties, nil
+		}
+
+		open := strings.IndexByte(body, '[')
+		closing := strings.IndexByte(body, ']')
+		if open < 0 || closing < open || !isTag(strings.TrimSpace(body[:open])) {
+			return nil, fmt.Errorf("%w: %q is not a property", ErrNotGGF, body)
+		}
+
+		properties = append(properties, property{tag: strings.TrimSpace(body[:open]), value: body[open+1 : closing]})
+		body = body[closing+1:]
+	}
+}
+
+// parseSeconds reads seconds, mm:ss or hh:mm:ss, the seconds may have a
+// fraction.
+func parseSeconds(text string) (time.Duration, error) {
+	parts := strings.Split(strings.TrimSpace(text), ":")
+	if len(parts) > 3 {
+		return 0, fmt.Errorf("%w: %q is not a time", ErrNotGGF, text)
+	}
+
+	seconds := 0.0
+	for _, part := range parts {
+		value, err := strconv.ParseFloat(part, 64)
+		if err != nil || value < 0 {
+			return 0, fmt.Errorf("%w: %q is not a time", ErrNotGGF, text)
+		}
+		seconds = seconds*60 + value
+	}
+
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// parseMove reads a move, PA for a pass, then optionally its evaluation and
+// the seconds it took, as in F5/1.50/2.3.
+func parseMove(side core.Player, text string) (Move, error) {
+	fields := strings.Split(text, "/")
+	move := Move{Side: side}
+
+	if strings.ToUpper(strings.TrimSpace(fields[0])) == passMove {
+		move.Pass = true
+	} else {
+		square, err := core.ParseMove(fields[0])
+		if err != nil {
+			return Move{}, fmt.Errorf("%w: %s", ErrNotGGF, err.Error())
+		}
+		move.Square = square
+	}
+
+	if len(fields) > 1 && strings.TrimSpace(fields[1]) != "" {
+		evaluation, err := strconv.ParseFloat(strings.TrimSpace(fields[1]), 64)
+		if err != nil {
+			return Move{}, fmt.Errorf("%w: %q is not an evaluation", ErrNotGGF, fields[1])
+		}
+		move.Evaluation, move.HasEvaluation = evaluation, true
+	}
+
+	if len(fields) > 2 && strings.TrimSpace(fields[2]) != "" {
+		taken, err := parseSeconds(fields[2])
+		if err != nil {
+			return Move{}, err
+		}
+		move.Time = taken
+	}
+
+	return move, nil
+}
+
+// parseBoard reads the size, the squares and the side to move of BO.
+func parseBoard(text string) (core.GameState, error) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 || fields[0] != boardSize {
+		return core.GameState{}, fmt.Errorf("%w: the board is %q", ErrUnsupportedGame, text)
+	}
+
+	start, err := core.ParsePosition(strings.Join(fields[1:], ""))
+	if err != nil {
+		return core.GameState{}, fmt.Errorf("%w: %s", ErrNotGGF, err.Error())
+	}
+
+	return start, nil
+}
+
+func parseRating(text string) (float64, error) {
+	rating, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q is not a rating", ErrNotGGF, text)
+	}
+
+	return rating, nil
+}
+
+// Parse reads one game. The moves are not checked, Replay does that, and
+// properties other than those of Game, such as comments, are skipped.
+func Parse(text string) (Game, error) {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "(;") || !strings.HasSuffix(text, ";)") {
+		return Game{}, fmt.Errorf("%w: a game starts with (; and ends with ;)", ErrNotGGF)
+	}
+
+	properties, err := propertiesOf(text[2 : len(text)-2])
+	if err != nil {
+		return Game{}, err
+	}
+
+	game := Game{}
+	hasBoard := false
+	for _, found := range properties {
+		value := strings.TrimSpace(found.value)
+
+		switch found.tag {
+		case "GM":
+			if !strings.EqualFold(value, othello) {
+				return Game{}, fmt.Errorf("%w: the game is %q", ErrUnsupportedGame, value)
+			}
+		case "TY":
+			if value != boardSize {
+				return Game{}, fmt.Errorf("%w: the type is %q", ErrUnsupportedGame, value)
+			}
+		case "PC":
+			game.Place = value
+		case "DT":
+			game.Date = value
+		case "PB":
+			game.Black = value
+		case "PW":
+			game.White = value
+		case "RB":
+			game.BlackRating, err = parseRating(value)
+		case "RW":
+			game.WhiteRating, err = parseRating(value)
+		case "TI":
+			game.TimeControl = value
+		case "RE":
+			game.Result = value
+		case "BO":
+			game.Start, err = parseBoard(value)
+			hasBoard = true
+		case "B", "W":
+			side := core.BLACK
+			if found.tag == "W" {
+				side = core.WHITE
+			}
+
+			var move Move
+			move, err = parseMove(side, value)
+			game.Moves = append(game.Moves, move)
+		}
+
+		if err != nil {
+			return Game{}, err
+		}
+	}
+
+	if !hasBoard {
+		return Game{}, fmt.Errorf("%w: the game has no board", ErrNotGGF)
+	}
+
+	return game, nil
+}
+
+// ParseAll reads every game of a GGF file, each from (; to ;) and usually
+// one a line.
+func ParseAll(text string) ([]Game, error) {
+	games := []Game{}
+	for {
+		start := strings.Index(text, "(;")
+		if start < 0 {
+			return games, nil
+		}
+
+		end := strings.Index(text[start:], ";)")
+		if end < 0 {
+			return nil, fmt.Errorf("%w: game %d does not end", ErrNotGGF, len(games)+1)
+		}
+
+		game, err := Parse(text[start : start+end+2])
+		if err != nil {
+			return nil, fmt.Errorf("game %d: %w", len(games)+1, err)
+		}
+		games = append(games, game)
+		text = text[start+end+2:]
+	}
+}
@@ -0,0 +1,191 @@
+package ggf
+
+import (
+	"errors"
+	"fmt"
+	"reversi/core"
+	"reversi/records"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	place = "reversi"
+	// forfeitMargin is the score of a game won by resignation or on time.
+	forfeitMargin = 64
+)
+
+var (
+	ErrUnfinished   = errors.New("the game is not over")
+	ErrOtherOpening = errors.New("the game does not start from a starting board")
+)
+
+// clockOf turns a time control such as 5+3, minutes and seconds added a
+// move, into the GGF clock 05:00/00:03. Anything else is kept as it is.
+func clockOf(timeControl string) string {
+	parts := strings.Split(timeControl, "+")
+	if len(parts) != 2 {
+		return timeControl
+	}
+
+	minutes, minutesErr := strconv.Atoi(parts[0])
+	increment, incrementErr := strconv.Atoi(parts[1])
+	if minutesErr != nil || incrementErr != nil || minutes < 0 || increment < 0 || increment > 59 {
+		return timeControl
+	}
+
+	return fmt.Sprintf("%02d:00/00:%02d", minutes, increment)
+}
+
+// timeControlOf reads a GGF clock back as minutes+seconds when it is one,
+// otherwise the clock is kept as it is.
+func timeControlOf(clock string) string {
+	parts := strings.Split(clock, "/")
+	if len(parts) > 3 || (len(parts) == 3 && parts[2] != "") {
+		return clock
+	}
+
+	main, err := parseSeconds(parts[0])
+	if err != nil || main%time.Minute != 0 {
+		return clock
+	}
+
+	increment := time.Duration(0)
+	if len(parts) > 1 && parts[1] != "" {
+		increment, err = parseSeconds(parts[1])
+		if err != nil || increment%time.Second != 0 {
+			return clock
+		}
+	}
+
+	return fmt.Sprintf("%d+%d", int(main.Minutes()), int(increment.Seconds()))
+}
+
+// resultOf is the result from black's point of view, a forfeit is written as
+// a resignation.
+func resultOf(record records.GameRecord) string {
+	if record.Forfeit != "" {
+		if record.Winner == core.BLACK {
+			return fmt.Sprintf("+%d:%s", forfeitMargin, resigned)
+		}
+		return fmt.Sprintf("-%d:%s", forfeitMargin, resigned)
+	}
+
+	margin := record.BlackDiscs - record.WhiteDiscs
+	if margin == 0 {
+		return "0"
+	}
+
+	return fmt.Sprintf("%+d", margin)
+}
+
+// FromRecord writes a game the server recorded, with the players' ratings
+// when they are not 0.
+func FromRecord(record records.GameRecord, blackRating float64, whiteRating float64) (Game, error) {
+	start := core.StartingPosition()
+
+	moves, err := MovesFrom(start, record.Moves)
+	if err != nil {
+		return Game{}, err
+	}
+
+	game := Game{
+		Place:       place,
+		Black:       record.Black,
+		White:       record.White,
+		BlackRating: blackRating,
+		WhiteRating: whiteRating,
+		TimeControl: clockOf(record.TimeControl),
+		Result:      resultOf(record),
+		Start:       start,
+		Moves:       moves,
+	}
+	if !record.StartedAt.IsZero() {
+		game.Date = record.StartedAt.UTC().Format(dateLayout)
+	}
+
+	return game, nil
+}
+
+// mirrored is the game reflected top to bottom, which turns the standard
+// starting board of other servers into ours.
+func (game Game) mirrored() (Game, error) {
+	encoded := game.Start.Encode()
+	rows := make([]string, 8)
+	for y := range rows {
+		rows[7-y] = encoded[y*8 : y*8+8]
+	}
+
+	start, err := core.ParsePosition(strings.Join(rows, "") + encoded[64:])
+	if err != nil {
+		return Game{}, err
+	}
+
+	reflected := game
+	reflected.Start = start
+	reflected.Moves = make([]Move, len(game.Moves))
+	for i, move := range game.Moves {
+		move.Square.Y = 7 - move.Square.Y
+		reflected.Moves[i] = move
+	}
+
+	return reflected, nil
+}
+
+// forfeitedBy is the side that resigned or lost on time, none when the game
+// was played out.
+func (game Game) forfeitedBy() (core.Player, bool) {
+	parts := strings.SplitN(game.Result, ":", 2)
+	if len(parts) != 2 || (parts[1] != resigned && parts[1] != timedOut) {
+		return "", false
+	}
+
+	if strings.HasPrefix(parts[0], "-") {
+		return core.BLACK, true
+	}
+	return core.WHITE, true
+}
+
+// Record turns a game into a record of the server, checking every move. The
+// game has to start from either starting board, a game from the standard one
+// is mirrored, and either be over or have been resigned or lost on time.
+func (game Game) Record(gameId string) (records.GameRecord, error) {
+	if !game.Start.SamePosition(core.StartingPosition()) {
+		reflected, err := game.mirrored()
+		if err != nil || !reflected.Start.SamePosition(core.StartingPosition()) {
+			return records.GameRecord{}, ErrOtherOpening
+		}
+		game = reflected
+	}
+
+	states, err := game.Replay()
+	if err != nil {
+		return records.GameRecord{}, err
+	}
+	final := states[len(states)-1]
+
+	record := records.GameRecord{
+		GameId:      gameId,
+		Black:       game.Black,
+		White:       game.White,
+		Moves:       squaresOf(game.Moves),
+		BlackDiscs:  final.DiscCount(core.BLACK),
+		WhiteDiscs:  final.DiscCount(core.WHITE),
+		TimeControl: timeControlOf(game.TimeControl),
+	}
+	if started, err := time.Parse(dateLayout, game.Date); err == nil {
+		record.StartedAt = started
+	}
+
+	if loser, forfeited := game.forfeitedBy(); forfeited {
+		record.Winner, record.Forfeit = loser.Opposite(), loser
+		return record, nil
+	}
+	if !final.IsOver() {
+		return records.GameRecord{}, ErrUnfinished
+	}
+
+	record.Winner, _ = final.Winner()
+	return record, nil
+}
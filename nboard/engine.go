@@ -0,0 +1,300 @@
+package nboard
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os/exec"
+	"reversi/core"
+	"reversi/engine"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	protocolVersion = 2
+	startupTimeout  = 10 * time.Second
+	// Without a time limit the engine is still given up on eventually.
+	unlimitedTimeout = 5 * time.Minute
+	// answerGrace is how much longer than the time limit an engine may take
+	// to answer before it is given up on.
+	answerGrace  = 2 * time.Second
+	closeTimeout = 2 * time.Second
+)
+
+var (
+	ErrNoCommand     = errors.New("no engine command given")
+	ErrEngineStopped = errors.New("the engine stopped")
+	ErrEngineTimeout = errors.New("the engine did not answer in time")
+	ErrIllegalMove   = errors.New("the engine played an illegal move")
+)
+
+type Options struct {
+	// Command is the engine executable followed by its arguments.
+	Command []string
+	// Depth is sent with "set depth", a limit with a depth overrides it.
+	Depth int
+}
+
+// Engine drives an external program that speaks the NBoard protocol over its
+// standard input and output. It plays as an engine.Player, and when it is
+// sent the game's events it gives the engine the whole game rather than just
+// the current position.
+type Engine struct {
+	lock    sync.Mutex
+	process *exec.Cmd
+	input   io.WriteCloser
+	lines   <-chan string
+	name    string
+	depth   int
+	pings   int
+	history []core.Coordinate
+}
+
+func readLines(output io.Reader, lines chan<- string) {
+	scanner := bufio.NewScanner(output)
+	for scanner.Scan() {
+		lines <- strings.TrimSpace(scanner.Text())
+	}
+
+	close(lines)
+}
+
+func (external *Engine) send(command string) error {
+	_, err := io.WriteString(external.input, command+"\n")
+	if err != nil {
+		return ErrEngineStopped
+	}
+
+	return nil
+}
+
+// waitFor skips lines until one starts with prefix, noting the engine's name
+// if it reports one on the way.
+func (external *Engine) waitFor(prefix string, timeout time.Duration) (string, error) {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	for {
+		select {
+		case line, open := <-external.lines:
+			if !open {
+				return "", ErrEngineStopped
+			}
+			if strings.HasPrefix(line, "set myname ") {
+				external.name = strings.TrimSpace(strings.TrimPrefix(line, "set myname "))
+			}
+			if strings.HasPrefix(line, prefix) {
+				return line, nil
+			}
+		case <-deadline.C:
+			return "", ErrEngineTimeout
+		}
+	}
+}
+
+// synchronise waits until the engine has handled everything sent so far, any
+// late answer to an earlier search is skipped.
+func (external *Engine) synchronise(timeout time.Duration) error {
+	external.pings++
+	err := external.send(fmt.Sprintf("ping %d", external.pings))
+	if err != nil {
+		return err
+	}
+
+	_, err = external.waitFor(fmt.Sprintf("pong %d", external.pings), timeout)
+	return err
+}
+
+func (external *Engine) setDepth(depth int) error {
+	if depth <= 0 || depth == external.depth {
+		return nil
+	}
+
+	external.depth = depth
+	return external.send(fmt.Sprintf("set depth %d", depth))
+}
+
+// Name is the name the engine reported for itself, if any.
+func (external *Engine) Name() string {
+	external.lock.Lock()
+	defer external.lock.Unlock()
+
+	return external.name
+}
+
+func (external *Engine) SendEvent(event core.Event) {
+	external.lock.Lock()
+	defer external.lock.Unlock()
+
+	if event.EventType == core.INITILIZED {
+		external.history = []core.Coordinate{}
+	}
+
+	if event.EventType == core.MOVED && external.history != nil {
+		external.history = append(external.history, event.Data.(core.Coordinate))
+	}
+}
+
+// game is the game to send for the position, the whole game when the events
+// received lead to it and otherwise just the position.
+func (external *Engine) game(gameState core.GameState) string {
+	if external.history == nil {
+		return gameRecord(gameState, nil)
+	}
+
+	states, err := core.Replay(core.StartingPosition(), external.history)
+	if err != nil || !states[len(states)-1].SamePosition(gameState) {
+		return gameRecord(gameState, nil)
+	}
+
+	return gameRecord(states[0], external.history)
+}
+
+func answerTimeout(limits engine.Limits) time.Duration {
+	if limits.Time <= 0 {
+		return unlimitedTimeout
+	}
+
+	return limits.Time + answerGrace
+}
+
+// parseAnswer reads "=== F5/-2.00/1.5", the evaluation and time are
+// optional.
+func parseAnswer(line string) (core.Coordinate, float64, error) {
+	fields := strings.Split(strings.TrimSpace(strings.TrimPrefix(line, "===")), "/")
+
+	move, pass, err := parseSquare(fields[0])
+	if err != nil || pass {
+		return core.Coordinate{}, 0, ErrIllegalMove
+	}
+
+	evaluation := 0.0
+	if len(fields) > 1 {
+		evaluation, _ = strconv.ParseFloat(strings.TrimSpace(fields[1]), 64)
+	}
+
+	return move, evaluation, nil
+}
+
+func (external *Engine) BestMove(gameState core.GameState, limits engine.Limits) (engine.Result, error) {
+	started := time.Now()
+
+	external.lock.Lock()
+	defer external.lock.Unlock()
+
+	if len(gameState.MoveOptions()) == 0 {
+		return engine.Result{}, engine.ErrNoMoves
+	}
+
+	err := external.setDepth(limits.Depth)
+	if err != nil {
+		return engine.Result{}, err
+	}
+
+	err = external.send("set game " + external.game(gameState))
+	if err != nil {
+		return engine.Result{}, err
+	}
+
+	err = external.synchronise(startupTimeout)
+	if err != nil {
+		return engine.Result{}, err
+	}
+
+	err = external.send("go")
+	if err != nil {
+		return engine.Result{}, err
+	}
+
+	answer, err := external.waitFor("===", answerTimeout(limits))
+	if err != nil {
+		return engine.Result{}, err
+	}
+
+	move, evaluation, err := parseAnswer(answer)
+	if err != nil || !gameState.MoveOptions()[move] {
+		return engine.Result{}, fmt.Errorf("%w: %s", ErrIllegalMove, answer)
+	}
+
+	return engine.Result{
+		Move:               move,
+		Score:              int(math.Round(evaluation * 100)),
+		Depth:              external.depth,
+		PrincipalVariation: []core.Coordinate{move},
+		Elapsed:            time.Since(started),
+	}, nil
+}
+
+// Close asks the engine to quit and stops it if it does not.
+func (external *Engine) Close() error {
+	external.lock.Lock()
+	defer external.lock.Unlock()
+
+	external.send("quit")
+	external.input.Close()
+	go func() {
+		for range external.lines {
+		}
+	}()
+
+	exited := make(chan error, 1)
+	go func() { exited <- external.process.Wait() }()
+
+	select {
+	case err := <-exited:
+		return err
+	case <-time.After(closeTimeout):
+		external.process.Process.Kill()
+		return <-exited
+	}
+}
+
+// Start launches the engine and waits until it answers.
+func Start(options Options) (*Engine, error) {
+	if len(options.Command) == 0 {
+		return nil, ErrNoCommand
+	}
+
+	process := exec.Command(options.Command[0], options.Command[1:]...)
+	input, err := process.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	output, err := process.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	err = process.Start()
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make(chan string, 64)
+	go readLines(output, lines)
+
+	external := &Engine{
+		process: process,
+		input:   input,
+		lines:   lines,
+	}
+
+	err = external.send(fmt.Sprintf("nboard %d", protocolVersion))
+	if err == nil {
+		err = external.setDepth(options.Depth)
+	}
+	if err == nil {
+		err = external.synchronise(startupTimeout)
+	}
+	if err != nil {
+		external.Close()
+		return nil, err
+	}
+
+	return external, nil
+}
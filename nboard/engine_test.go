@@ -0,0 +1,122 @@
+package nboard_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reversi/core"
+	"reversi/engine"
+	"reversi/nboard"
+	"strings"
+	"testing"
+	"time"
+)
+
+const initialBoard = "BO[8 ---------------------------*O------O*--------------------------- *]"
+
+func stateAfter(moves []core.Coordinate) core.GameState {
+	states, err := core.Replay(core.StartingPosition(), moves)
+	if err != nil {
+		panic(err)
+	}
+
+	return states[len(states)-1]
+}
+
+// startFakeEngine starts the fake engine answering with move, the returned
+// function reads back what it was sent.
+func startFakeEngine(t *testing.T, move string) (*nboard.Engine, func() string) {
+	directory, err := ioutil.TempDir("", "nboard")
+	if err != nil {
+		t.Fatalf("failed to create a temporary directory: %s", err.Error())
+	}
+	log := filepath.Join(directory, "commands.log")
+
+	os.Setenv("FAKE_ENGINE_LOG", log)
+	os.Setenv("FAKE_ENGINE_MOVE", move)
+
+	external, err := nboard.Start(nboard.Options{Command: []string{"sh", "testdata/fakeEngine.sh"}, Depth: 4})
+	if err != nil {
+		t.Fatalf("Expected the fake engine to start, instead got %s", err.Error())
+	}
+
+	t.Cleanup(func() {
+		external.Close()
+		os.RemoveAll(directory)
+		os.Unsetenv("FAKE_ENGINE_LOG")
+		os.Unsetenv("FAKE_ENGINE_MOVE")
+	})
+
+	return external, func() string {
+		data, _ := ioutil.ReadFile(log)
+		return string(data)
+	}
+}
+
+func Test_Engine_playsTheMoveItAnswersWith(t *testing.T) {
+	external, commands := startFakeEngine(t, "c5")
+
+	result, err := external.BestMove(stateAfter(nil), engine.Limits{Time: time.Second})
+	if err != nil {
+		t.Fatalf("Expected a move, instead got %s", err.Error())
+	}
+
+	if result.Move != (core.Coordinate{X: 2, Y: 4}) || result.Score != 250 || result.Depth != 4 {
+		t.Errorf("Expected C5 scored 250 at depth 4, instead got %+v", result)
+	}
+	if external.Name() != "fake" {
+		t.Errorf("Expected the engine to be called fake, instead got %q", external.Name())
+	}
+
+	sent := commands()
+	for _, expected := range []string{"nboard 2", "set depth 4", "set game (;GM[Othello]", initialBoard + ";)", "go"} {
+		if !strings.Contains(sent, expected) {
+			t.Errorf("Expected the engine to be sent %q, instead it was sent:\n%s", expected, sent)
+		}
+	}
+}
+
+func Test_Engine_followingTheEvents_isSentTheWholeGame(t *testing.T) {
+	external, commands := startFakeEngine(t, "c3")
+
+	moves := []core.Coordinate{{X: 2, Y: 4}, {X: 2, Y: 3}}
+	external.SendEvent(core.NewInitializedEvent())
+	for _, move := range moves {
+		external.SendEvent(core.NewMoveEvent(move))
+	}
+
+	_, err := external.BestMove(stateAfter(moves), engine.Limits{Time: time.Second})
+	if err != nil {
+		t.Fatalf("Expected a move, instead got %s", err.Error())
+	}
+
+	if !strings.Contains(commands(), initialBoard+"B[C5]W[C4];)") {
+		t.Errorf("Expected the game from the start, instead the engine was sent:\n%s", commands())
+	}
+}
+
+func Test_Engine_answeringWithAnIllegalMove_isAnError(t *testing.T) {
+	external, _ := startFakeEngine(t, "a1")
+
+	_, err := external.BestMove(stateAfter(nil), engine.Limits{Time: time.Second})
+	if !errors.Is(err, nboard.ErrIllegalMove) {
+		t.Errorf("Expected an illegal move error, instead got %v", err)
+	}
+}
+
+func Test_Engine_thatNeverAnswers_isGivenUpOn(t *testing.T) {
+	external, _ := startFakeEngine(t, "none")
+
+	_, err := external.BestMove(stateAfter(nil), engine.Limits{Time: 10 * time.Millisecond})
+	if err != nboard.ErrEngineTimeout {
+		t.Errorf("Expected a timeout, instead got %v", err)
+	}
+}
+
+func Test_Start_withAMissingExecutable_fails(t *testing.T) {
+	_, err := nboard.Start(nboard.Options{Command: []string{"testdata/missing-engine"}})
+	if err == nil {
+		t.Error("Expected starting a missing engine to fail")
+	}
+}
@@ -0,0 +1,35 @@
+package nboard
+
+import (
+	"reversi/core"
+	"reversi/ggf"
+	"strings"
+)
+
+const passMove = "PA"
+
+// squareName writes the coordinate the way NBoard does, in upper case.
+func squareName(coordinate core.Coordinate) string {
+	return strings.ToUpper(coordinate.String())
+}
+
+// parseSquare reads a move in either case, PA is a pass.
+func parseSquare(text string) (core.Coordinate, bool, error) {
+	if strings.ToUpper(strings.TrimSpace(text)) == passMove {
+		return core.Coordinate{}, true, nil
+	}
+
+	move, err := core.ParseMove(text)
+	return move, false, err
+}
+
+// gameRecord writes a game in the Generic Game Format NBoard engines are sent
+// with "set game", without the moves when they cannot be played from start.
+func gameRecord(start core.GameState, squares []core.Coordinate) string {
+	moves, err := ggf.MovesFrom(start, squares)
+	if err != nil {
+		moves = nil
+	}
+
+	return ggf.Game{Place: "reversi", Start: start, Moves: moves}.String()
+}
@@ -0,0 +1,18 @@
+#!/bin/sh
+# Stands in for an NBoard engine in the tests. It writes every command it is
+# sent to $FAKE_ENGINE_LOG and answers "go" with $FAKE_ENGINE_MOVE, or not at
+# all when that is "none".
+echo "set myname fake"
+while read -r command arguments; do
+	echo "$command $arguments" >> "$FAKE_ENGINE_LOG"
+	case "$command" in
+	ping) echo "pong $arguments" ;;
+	go)
+		echo "status thinking"
+		if [ "$FAKE_ENGINE_MOVE" != "none" ]; then
+			echo "=== $FAKE_ENGINE_MOVE/2.50/0.1"
+		fi
+		;;
+	quit) exit 0 ;;
+	esac
+done
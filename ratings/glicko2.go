@@ -0,0 +1,123 @@
+package ratings
+
+import "math"
+
+// Glicko-2 as described in http://www.glicko.net/glicko/glicko2.pdf, with
+// each rated game treated as its own rating period.
+
+const (
+	InitialRating     = 1500.0
+	InitialDeviation  = 350.0
+	InitialVolatility = 0.06
+
+	scale     = 173.7178
+	tau       = 0.5
+	tolerance = 0.000001
+)
+
+type Rating struct {
+	Rating     float64
+	Deviation  float64
+	Volatility float64
+}
+
+type Result struct {
+	Opponent Rating
+	Score    float64
+}
+
+func NewRating() Rating {
+	return Rating{
+		Rating:     InitialRating,
+		Deviation:  InitialDeviation,
+		Volatility: InitialVolatility,
+	}
+}
+
+func g(phi float64) float64 {
+	return 1 / math.Sqrt(1+3*phi*phi/(math.Pi*math.Pi))
+}
+
+func expectedScore(mu float64, opponentMu float64, opponentPhi float64) float64 {
+	return 1 / (1 + math.Exp(-g(opponentPhi)*(mu-opponentMu)))
+}
+
+func newVolatility(sigma float64, phi float64, variance float64, delta float64) float64 {
+	a := math.Log(sigma * sigma)
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		numerator := ex * (delta*delta - phi*phi - variance - ex)
+		denominator := 2 * (phi*phi + variance + ex) * (phi*phi + variance + ex)
+		return numerator/denominator - (x-a)/(tau*tau)
+	}
+
+	upper := a
+	var lower float64
+	if delta*delta > phi*phi+variance {
+		lower = math.Log(delta*delta - phi*phi - variance)
+	} else {
+		k := 1.0
+		for f(a-k*tau) < 0 {
+			k++
+		}
+		lower = a - k*tau
+	}
+
+	fUpper := f(upper)
+	fLower := f(lower)
+	for math.Abs(lower-upper) > tolerance {
+		candidate := upper + (upper-lower)*fUpper/(fLower-fUpper)
+		fCandidate := f(candidate)
+
+		if fCandidate*fLower <= 0 {
+			upper = lower
+			fUpper = fLower
+		} else {
+			fUpper = fUpper / 2
+		}
+
+		lower = candidate
+		fLower = fCandidate
+	}
+
+	return math.Exp(upper / 2)
+}
+
+func Update(player Rating, results []Result) Rating {
+	mu := (player.Rating - InitialRating) / scale
+	phi := player.Deviation / scale
+
+	if len(results) == 0 {
+		phiStar := math.Sqrt(phi*phi + player.Volatility*player.Volatility)
+		return Rating{
+			Rating:     player.Rating,
+			Deviation:  phiStar * scale,
+			Volatility: player.Volatility,
+		}
+	}
+
+	inverseVariance := 0.0
+	improvement := 0.0
+	for _, result := range results {
+		opponentMu := (result.Opponent.Rating - InitialRating) / scale
+		opponentPhi := result.Opponent.Deviation / scale
+
+		expected := expectedScore(mu, opponentMu, opponentPhi)
+		inverseVariance += g(opponentPhi) * g(opponentPhi) * expected * (1 - expected)
+		improvement += g(opponentPhi) * (result.Score - expected)
+	}
+	variance := 1 / inverseVariance
+	delta := variance * improvement
+
+	sigma := newVolatility(player.Volatility, phi, variance, delta)
+	phiStar := math.Sqrt(phi*phi + sigma*sigma)
+
+	updatedPhi := 1 / math.Sqrt(1/(phiStar*phiStar)+1/variance)
+	updatedMu := mu + updatedPhi*updatedPhi*improvement
+
+	return Rating{
+		Rating:     updatedMu*scale + InitialRating,
+		Deviation:  updatedPhi * scale,
+		Volatility: sigma,
+	}
+}
@@ -0,0 +1,45 @@
+package ratings_test
+
+import (
+	"math"
+	"reversi/ratings"
+	"testing"
+)
+
+func closeTo(actual float64, expected float64, within float64) bool {
+	return math.Abs(actual-expected) <= within
+}
+
+func Test_Update_matchesTheGlickoPaperExample(t *testing.T) {
+	player := ratings.Rating{Rating: 1500, Deviation: 200, Volatility: 0.06}
+	results := []ratings.Result{
+		{Opponent: ratings.Rating{Rating: 1400, Deviation: 30, Volatility: 0.06}, Score: 1},
+		{Opponent: ratings.Rating{Rating: 1550, Deviation: 100, Volatility: 0.06}, Score: 0},
+		{Opponent: ratings.Rating{Rating: 1700, Deviation: 300, Volatility: 0.06}, Score: 0},
+	}
+
+	updated := ratings.Update(player, results)
+
+	if !closeTo(updated.Rating, 1464.06, 0.01) {
+		t.Errorf("Expected a rating of 1464.06, instead got %f", updated.Rating)
+	}
+	if !closeTo(updated.Deviation, 151.52, 0.01) {
+		t.Errorf("Expected a deviation of 151.52, instead got %f", updated.Deviation)
+	}
+	if !closeTo(updated.Volatility, 0.05999, 0.00001) {
+		t.Errorf("Expected a volatility of 0.05999, instead got %f", updated.Volatility)
+	}
+}
+
+func Test_Update_withoutGames_onlyWidensTheDeviation(t *testing.T) {
+	player := ratings.Rating{Rating: 1600, Deviation: 50, Volatility: 0.06}
+
+	updated := ratings.Update(player, []ratings.Result{})
+
+	if updated.Rating != 1600 {
+		t.Errorf("The rating should not change, instead got %f", updated.Rating)
+	}
+	if !(updated.Deviation > 50) {
+		t.Errorf("The deviation should grow, instead got %f", updated.Deviation)
+	}
+}
@@ -0,0 +1,174 @@
+package ratings
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"reversi/core"
+	"reversi/records"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type PlayerRating struct {
+	Name string
+	Rating
+	Games  int
+	Wins   int
+	Losses int
+	Draws  int
+}
+
+type RatingChange struct {
+	Name   string
+	Before float64
+	After  float64
+}
+
+type Store struct {
+	lock    sync.Mutex
+	path    string
+	players map[string]PlayerRating
+}
+
+func key(name string) string {
+	return strings.ToLower(name)
+}
+
+func (store *Store) save() error {
+	data, err := json.MarshalIndent(store.players, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	temporary := store.path + ".tmp"
+	err = ioutil.WriteFile(temporary, data, 0644)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(temporary, store.path)
+}
+
+func (store *Store) get(name string) PlayerRating {
+	if player, found := store.players[key(name)]; found {
+		return player
+	}
+
+	return PlayerRating{Name: name, Rating: NewRating()}
+}
+
+func (store *Store) Get(name string) PlayerRating {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	return store.get(name)
+}
+
+func scoreFor(side core.Player, record records.GameRecord) float64 {
+	if record.IsDraw() {
+		return 0.5
+	}
+	if record.Winner == side {
+		return 1
+	}
+
+	return 0
+}
+
+func tally(player PlayerRating, updated Rating, score float64) PlayerRating {
+	player.Rating = updated
+	player.Games++
+
+	switch score {
+	case 1:
+		player.Wins++
+	case 0:
+		player.Losses++
+	default:
+		player.Draws++
+	}
+
+	return player
+}
+
+// RecordGame updates the ratings of both players of a rated game and returns
+// how each player's rating changed. Unrated games change nothing.
+func (store *Store) RecordGame(record records.GameRecord) (map[core.Player]RatingChange, error) {
+	changes := make(map[core.Player]RatingChange)
+	if !record.Rated {
+		return changes, nil
+	}
+
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	black := store.get(record.Black)
+	white := store.get(record.White)
+
+	blackScore := scoreFor(core.BLACK, record)
+	whiteScore := scoreFor(core.WHITE, record)
+
+	updatedBlack := tally(black, Update(black.Rating, []Result{{Opponent: white.Rating, Score: blackScore}}), blackScore)
+	updatedWhite := tally(white, Update(white.Rating, []Result{{Opponent: black.Rating, Score: whiteScore}}), whiteScore)
+
+	store.players[key(record.Black)] = updatedBlack
+	store.players[key(record.White)] = updatedWhite
+
+	err := store.save()
+	if err != nil {
+		store.players[key(record.Black)] = black
+		store.players[key(record.White)] = white
+		return changes, err
+	}
+
+	changes[core.BLACK] = RatingChange{Name: black.Name, Before: black.Rating.Rating, After: updatedBlack.Rating.Rating}
+	changes[core.WHITE] = RatingChange{Name: white.Name, Before: white.Rating.Rating, After: updatedWhite.Rating.Rating}
+
+	return changes, nil
+}
+
+func (store *Store) Leaderboard(limit int) []PlayerRating {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	result := make([]PlayerRating, 0, len(store.players))
+	for _, player := range store.players {
+		result = append(result, player)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Rating.Rating == result[j].Rating.Rating {
+			return result[i].Name < result[j].Name
+		}
+		return result[i].Rating.Rating > result[j].Rating.Rating
+	})
+
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+
+	return result
+}
+
+func NewStore(path string) (*Store, error) {
+	players := make(map[string]PlayerRating)
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	if err == nil && len(data) > 0 {
+		err = json.Unmarshal(data, &players)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &Store{
+		path:    path,
+		players: players,
+	}, nil
+}
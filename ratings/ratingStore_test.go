@@ -0,0 +1,91 @@
+package ratings_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reversi/core"
+	"reversi/ratings"
+	"reversi/records"
+	"testing"
+)
+
+func newTestStore(t *testing.T) (*ratings.Store, string) {
+	directory, err := ioutil.TempDir("", "ratings")
+	if err != nil {
+		t.Fatalf("failed to create a temporary directory: %s", err.Error())
+	}
+	t.Cleanup(func() { os.RemoveAll(directory) })
+
+	path := filepath.Join(directory, "ratings.json")
+	store, err := ratings.NewStore(path)
+	if err != nil {
+		t.Fatalf("failed to create the store: %s", err.Error())
+	}
+
+	return store, path
+}
+
+func Test_RatedGame_movesTheWinnerUpAndTheLoserDown(t *testing.T) {
+	store, _ := newTestStore(t)
+
+	changes, err := store.RecordGame(records.GameRecord{Black: "ada", White: "bob", Winner: core.BLACK, Rated: true})
+	if err != nil {
+		t.Fatalf("failed to record the game: %s", err.Error())
+	}
+
+	if !(changes[core.BLACK].After > changes[core.BLACK].Before) {
+		t.Errorf("The winner should gain rating: %+v", changes[core.BLACK])
+	}
+	if !(changes[core.WHITE].After < changes[core.WHITE].Before) {
+		t.Errorf("The loser should lose rating: %+v", changes[core.WHITE])
+	}
+
+	ada := store.Get("ada")
+	if ada.Games != 1 || ada.Wins != 1 {
+		t.Errorf("ada should have one win from one game: %+v", ada)
+	}
+	if !(ada.Deviation < ratings.InitialDeviation) {
+		t.Errorf("Playing a game should reduce the deviation, instead got %f", ada.Deviation)
+	}
+}
+
+func Test_UnratedGame_changesNothing(t *testing.T) {
+	store, _ := newTestStore(t)
+
+	changes, _ := store.RecordGame(records.GameRecord{Black: "ada", White: "bob", Winner: core.BLACK})
+
+	if len(changes) != 0 {
+		t.Errorf("An unrated game should not change ratings: %+v", changes)
+	}
+	if store.Get("ada").Games != 0 {
+		t.Error("An unrated game should not be counted")
+	}
+}
+
+func Test_Leaderboard_isOrderedByRatingAndPersisted(t *testing.T) {
+	store, path := newTestStore(t)
+
+	store.RecordGame(records.GameRecord{Black: "ada", White: "bob", Winner: core.BLACK, Rated: true})
+	store.RecordGame(records.GameRecord{Black: "cy", White: "bob", Rated: true})
+
+	reloaded, err := ratings.NewStore(path)
+	if err != nil {
+		t.Fatalf("failed to reload the store: %s", err.Error())
+	}
+
+	leaderboard := reloaded.Leaderboard(0)
+	if len(leaderboard) != 3 {
+		t.Fatalf("Expected 3 players, instead got %d", len(leaderboard))
+	}
+	if leaderboard[0].Name != "ada" || leaderboard[2].Name != "bob" {
+		t.Errorf("Expected ada first and bob last, instead got %s, %s, %s", leaderboard[0].Name, leaderboard[1].Name, leaderboard[2].Name)
+	}
+	if reloaded.Get("cy").Draws != 1 {
+		t.Error("cy should have a draw")
+	}
+
+	if len(reloaded.Leaderboard(2)) != 2 {
+		t.Error("The leaderboard should respect the limit")
+	}
+}
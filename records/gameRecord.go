@@ -0,0 +1,100 @@
+package records
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"reversi/core"
+	"sync"
+	"time"
+)
+
+type GameRecord struct {
+	GameId     string
+	Black      string
+	White      string
+	Moves      []core.Coordinate
+	BlackDiscs int
+	WhiteDiscs int
+	Winner     core.Player
+	// Forfeit is the side that left before the game was over.
+	Forfeit     core.Player
+	TimeControl string
+	Rated       bool
+	StartedAt   time.Time
+	FinishedAt  time.Time
+}
+
+func (record GameRecord) NameOf(side core.Player) string {
+	if side == core.BLACK {
+		return record.Black
+	}
+
+	return record.White
+}
+
+func (record GameRecord) IsDraw() bool {
+	return record.Winner == ""
+}
+
+// Store keeps finished games in a file, one JSON encoded record per line.
+type Store struct {
+	lock sync.Mutex
+	path string
+}
+
+func (store *Store) Append(record GameRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	file, err := os.OpenFile(store.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(data, '\n'))
+	return err
+}
+
+func (store *Store) ReadAll() ([]GameRecord, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	result := []GameRecord{}
+
+	file, err := os.Open(store.path)
+	if os.IsNotExist(err) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		record := GameRecord{}
+		err = json.Unmarshal(scanner.Bytes(), &record)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, record)
+	}
+
+	return result, scanner.Err()
+}
+
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
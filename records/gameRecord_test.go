@@ -0,0 +1,51 @@
+package records_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reversi/core"
+	"reversi/records"
+	"testing"
+)
+
+func Test_AppendedRecords_canBeReadBack(t *testing.T) {
+	directory, err := ioutil.TempDir("", "records")
+	if err != nil {
+		t.Fatalf("failed to create a temporary directory: %s", err.Error())
+	}
+	defer os.RemoveAll(directory)
+
+	store := records.NewStore(filepath.Join(directory, "games.jsonl"))
+
+	empty, err := store.ReadAll()
+	if err != nil || len(empty) != 0 {
+		t.Errorf("A missing file should read as no records, got %d records and %v", len(empty), err)
+	}
+
+	first := records.GameRecord{
+		GameId: "first",
+		Black:  "ada",
+		White:  "bob",
+		Moves:  []core.Coordinate{{X: 2, Y: 4}, {X: 2, Y: 3}},
+		Winner: core.BLACK,
+	}
+	second := records.GameRecord{GameId: "second", Black: "bob", White: "ada"}
+
+	store.Append(first)
+	store.Append(second)
+
+	games, err := store.ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read the records: %s", err.Error())
+	}
+	if len(games) != 2 {
+		t.Fatalf("Expected 2 records, instead got %d", len(games))
+	}
+	if games[0].GameId != "first" || len(games[0].Moves) != 2 || games[0].Moves[1] != (core.Coordinate{X: 2, Y: 3}) {
+		t.Errorf("The first record was not read back correctly: %+v", games[0])
+	}
+	if !games[1].IsDraw() {
+		t.Error("A record without a winner should be a draw")
+	}
+}
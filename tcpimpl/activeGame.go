@@ -9,10 +9,21 @@ import (
 
 type ActiveGame interface {
 	Start() error
+	Id() string
+	Watch(spectator *Spectator) error
 }
 
 type activeGameImpl struct {
-	players     []*ActivePlayer
+	id         string
+	players    []*ActivePlayer
+	spectators *spectatorGallery
+	mirror     core.StateUpdaterAndEventConsumer
+	// followers are sent every event before the mirror is updated.
+	followers   []core.EventConsumer
+	recorder    *gameRecorder
+	results     GameResultConsumer
+	announced   bool
+	over        chan bool
 	moveChannel <-chan InfrastructureCommand
 }
 
@@ -24,15 +35,20 @@ func asString(b bool) string {
 	}
 }
 
-func listenForCommands(commandChannel <-chan InfrastructureCommand, players []*ActivePlayer) {
-	factory := responderFactory{players: players}
+func listenForCommands(commandChannel <-chan InfrastructureCommand, factory responderFactory) {
+	players := factory.players
 
 	brain := core.NewGameBrain(factory.getSuccessInstance())
 	brain.Initialize(factory.getInstance(players[0].ResponseId))
 
-	for {
+	for !factory.game.announced {
 		command := <-commandChannel
 
+		if command.Forfeit {
+			factory.game.forfeit(command.ResponseId)
+			continue
+		}
+
 		brain.ExecuteCommand(
 			command.CoreCommand,
 			factory.getInstance(command.ResponseId),
@@ -41,38 +57,123 @@ func listenForCommands(commandChannel <-chan InfrastructureCommand, players []*A
 }
 
 func (activeGame *activeGameImpl) Start() error {
-	fmt.Println("Starting the game!")
+	fmt.Printf("Starting the game %s!\n", activeGame.id)
 
 	for _, player := range activeGame.players {
-		player.start()
+		player.start(activeGame.id, activeGame.opponentOf(player), activeGame.recorder.record.TimeControl, activeGame.recorder.record.Rated, activeGame.over)
 	}
 
-	go listenForCommands(activeGame.moveChannel, activeGame.players)
+	factory := responderFactory{
+		players: activeGame.players,
+		game:    activeGame,
+	}
+	go listenForCommands(activeGame.moveChannel, factory)
 
 	return nil
 }
 
-type InfrastructureCommand struct {
-	CoreCommand core.Command
-	ResponseId  uuid.UUID
+func (activeGame *activeGameImpl) Id() string {
+	return activeGame.id
 }
 
-func NewActiveGame(pendingGame PendingGame) ActiveGame {
-	players := pendingGame.players
-	blackPlayer := players[0]
-	whitePlayer := players[1]
+func (activeGame *activeGameImpl) Watch(spectator *Spectator) error {
+	return activeGame.spectators.admit(spectator)
+}
 
-	gameCommandChannel := make(chan InfrastructureCommand)
+func (activeGame *activeGameImpl) opponentOf(player *ActivePlayer) string {
+	for _, other := range activeGame.players {
+		if other != player {
+			return other.name
+		}
+	}
+
+	return ""
+}
+
+func (activeGame *activeGameImpl) eventOccurred(event core.Event, message string) {
+	activeGame.recorder.SendEvent(event)
+	for _, follower := range activeGame.followers {
+		follower.SendEvent(event)
+	}
+	activeGame.mirror.SendEvent(event)
+	activeGame.spectators.eventOccurred(message)
+
+	if activeGame.recorder.finished && !activeGame.announced {
+		activeGame.announced = true
+		activeGame.announce(activeGame.results.GameFinished(activeGame.recorder.record))
+	}
+}
+
+// forfeit ends the game when a player's connection is lost, the opponent
+// wins.
+func (activeGame *activeGameImpl) forfeit(responseId uuid.UUID) {
+	for _, player := range activeGame.players {
+		if player.RespondsFor(responseId) && !activeGame.announced {
+			fmt.Printf("%s left game %s\n", player.name, activeGame.id)
+			activeGame.recorder.forfeit(player.side)
+			activeGame.announced = true
+			activeGame.announce(activeGame.results.GameFinished(activeGame.recorder.record))
+		}
+	}
+}
 
-	blackGamePlayer := NewActivePlayer(core.BLACK, blackPlayer.Connection, gameCommandChannel)
-	whiteGamePlayer := NewActivePlayer(core.WHITE, whitePlayer.Connection, gameCommandChannel)
+func (activeGame *activeGameImpl) announce(gameOver GameOver) {
+	message, err := serialize(newGameOverNotification(gameOver))
+	if err != nil {
+		fmt.Printf("Failed to serialize the result of game %s: %s\n", activeGame.id, err.Error())
+	} else {
+		fmt.Println(message)
+		for _, player := range activeGame.players {
+			player.Notify(message)
+		}
+		activeGame.spectators.publish(message)
+	}
+
+	close(activeGame.over)
+	for _, player := range activeGame.players {
+		player.finish()
+	}
+	activeGame.spectators.close()
+}
 
+type InfrastructureCommand struct {
+	CoreCommand core.Command
+	ResponseId  uuid.UUID
+	// Forfeit is set instead of a command when the player has left.
+	Forfeit bool
+}
+
+func newActiveGame(blackGamePlayer *ActivePlayer, whiteGamePlayer *ActivePlayer, gameCommandChannel chan InfrastructureCommand, timeControl string, rated bool, results GameResultConsumer) *activeGameImpl {
 	gamePlayers := make([]*ActivePlayer, 2)
 	gamePlayers[0] = blackGamePlayer
 	gamePlayers[1] = whiteGamePlayer
 
+	gameId := uuid.New().String()
+
+	clock := newGameClock()
+	recorder := newGameRecorder(gameId, gamePlayers, timeControl, rated)
+
+	mirror := core.NewGameEventAggregator()
+	mirror.Register(clock)
+	mirror.Register(recorder)
+
 	return &activeGameImpl{
+		id:          gameId,
 		players:     gamePlayers,
+		spectators:  newSpectatorGallery(gameId, gamePlayers, clock),
+		mirror:      mirror,
+		recorder:    recorder,
+		results:     results,
+		over:        make(chan bool),
 		moveChannel: gameCommandChannel,
 	}
 }
+
+func NewActiveGame(match Match, rated bool, results GameResultConsumer) ActiveGame {
+	gameCommandChannel := make(chan InfrastructureCommand)
+
+	blackGamePlayer := NewActivePlayer(core.BLACK, match.Black, gameCommandChannel)
+	whiteGamePlayer := NewActivePlayer(core.WHITE, match.White, gameCommandChannel)
+
+	return newActiveGame(blackGamePlayer, whiteGamePlayer, gameCommandChannel, match.TimeControl, rated, results)
+}
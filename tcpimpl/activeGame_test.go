@@ -0,0 +1,41 @@
+package tcpimpl_test
+
+import (
+	"encoding/json"
+	"reversi/core"
+	"reversi/tcpimpl"
+	"testing"
+)
+
+func Test_PlayerThatDisconnects_forfeitsTheGame(t *testing.T) {
+	game := startTestGame(t)
+	spectator := game.watch(t)
+	game.play(t, wipeout[:3])
+
+	game.clients[core.BLACK].connection.Close()
+
+	line := game.clients[core.WHITE].expect(t, string(tcpimpl.GAME_OVER))
+	notification := struct{ Data tcpimpl.GameOver }{}
+	err := json.Unmarshal([]byte(line), &notification)
+	if err != nil {
+		t.Fatalf("failed to read the result: %s", err.Error())
+	}
+
+	if notification.Data.Winner != core.WHITE {
+		t.Errorf("WHITE should win when BLACK leaves, instead the winner is %s", notification.Data.Winner)
+	}
+
+	game.clients[core.WHITE].expectClosed(t)
+	spectator.expect(t, string(tcpimpl.GAME_OVER))
+	spectator.expectClosed(t)
+}
+
+func Test_FinishedGame_closesThePlayerConnections(t *testing.T) {
+	game := startTestGame(t)
+	game.play(t, wipeout)
+
+	for _, side := range []core.Player{core.BLACK, core.WHITE} {
+		game.clients[side].expect(t, string(tcpimpl.GAME_OVER))
+		game.clients[side].expectClosed(t)
+	}
+}
@@ -1,20 +1,27 @@
 package tcpimpl
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
 	"net"
 	"reversi/core"
+	"strings"
+	"sync"
 
 	"github.com/google/uuid"
 )
 
 type ActivePlayer struct {
 	side           core.Player
+	name           string
 	connection     net.Conn
+	reader         *bufio.Reader
 	ResponseId     uuid.UUID
 	commandChannel chan<- InfrastructureCommand
 	outputChannel  chan string
+	gone           chan bool
+	leaving        sync.Once
 }
 
 func (player *ActivePlayer) RespondsTo(side core.Player) bool {
@@ -22,11 +29,15 @@ func (player *ActivePlayer) RespondsTo(side core.Player) bool {
 }
 
 type SideAssigned struct {
-	Side core.Player
+	Side        core.Player
+	GameId      string
+	Opponent    string
+	TimeControl string
+	Rated       bool
 }
 
-func (player *ActivePlayer) notifyOfGameStart() error {
-	sideAssigned := SideAssigned{Side: player.side}
+func (player *ActivePlayer) notifyOfGameStart(gameId string, opponent string, timeControl string, rated bool) error {
+	sideAssigned := SideAssigned{Side: player.side, GameId: gameId, Opponent: opponent, TimeControl: timeControl, Rated: rated}
 	data, err := json.Marshal(sideAssigned)
 	if err != nil {
 		return err
@@ -37,69 +48,111 @@ func (player *ActivePlayer) notifyOfGameStart() error {
 	return nil
 }
 
-func (player *ActivePlayer) listenForPlayerInput() {
-	for {
-		data := make([]byte, 1024)
-		for {
-			offset, err := player.connection.Read(data)
-			if err != nil {
-				fmt.Errorf("Error reading data %s\n", err.Error())
-			}
-
-			if offset < 1 {
-				fmt.Errorf("Error reading data %s", "connection may be closed\n")
-				break
-			}
-
-			coordinate := core.Coordinate{}
-			json.Unmarshal(data[:offset], &coordinate)
-
-			fmt.Printf("Received Coordinate from client %s with value (%d, %d)\n", player.side, coordinate.X, coordinate.Y)
-
-			player.commandChannel <- InfrastructureCommand{
-				ResponseId:  player.ResponseId,
-				CoreCommand: core.NewMoveCommand(player.side, coordinate),
-			}
-		}
+// submit hands a command to the game unless the game is already over.
+func (player *ActivePlayer) submit(command InfrastructureCommand, over <-chan bool) bool {
+	select {
+	case player.commandChannel <- command:
+		return true
+	case <-over:
+		return false
 	}
 }
 
-func writeOutput(outputChannel <-chan string, connection net.Conn) {
+// leave is called when the connection is lost, the player forfeits a game
+// that is still going.
+func (player *ActivePlayer) leave(over <-chan bool) {
+	player.leaving.Do(func() {
+		close(player.gone)
+		player.connection.Close()
+		player.submit(InfrastructureCommand{ResponseId: player.ResponseId, Forfeit: true}, over)
+	})
+}
+
+func (player *ActivePlayer) listenForPlayerInput(over <-chan bool) {
 	for {
-		message := <-outputChannel
+		line, err := player.reader.ReadString('\n')
+		if err != nil {
+			fmt.Printf("Error reading data, connection may be closed: %s\n", err.Error())
+			player.leave(over)
+			return
+		}
+
+		coordinate := core.Coordinate{}
+		json.Unmarshal([]byte(strings.TrimSpace(line)), &coordinate)
+
+		fmt.Printf("Received the move %s from client %s\n", coordinate, player.side)
+
+		submitted := player.submit(InfrastructureCommand{
+			ResponseId:  player.ResponseId,
+			CoreCommand: core.NewMoveCommand(player.side, coordinate),
+		}, over)
+		if !submitted {
+			return
+		}
+	}
+}
 
-		_, err := connection.Write([]byte(message + "\n"))
+func (player *ActivePlayer) writeOutput(over <-chan bool) {
+	for message := range player.outputChannel {
+		_, err := player.connection.Write([]byte(message + "\n"))
 		if err != nil {
-			panic(err.Error())
+			fmt.Printf("Error writing to %s, connection may be closed: %s\n", player.name, err.Error())
+			player.leave(over)
+			return
 		}
 	}
+
+	player.connection.Close()
+}
+
+// discardOutput stands in for writeOutput when nobody is connected, the
+// computer follows the game through state updates instead.
+func (player *ActivePlayer) discardOutput() {
+	for range player.outputChannel {
+	}
 }
 
 func (player *ActivePlayer) Notify(message string) {
-	player.outputChannel <- message
+	select {
+	case player.outputChannel <- message:
+	case <-player.gone:
+	}
+}
+
+// finish closes the connection once everything sent to the player has been
+// written, nothing may be sent after it.
+func (player *ActivePlayer) finish() {
+	close(player.outputChannel)
 }
 
 func (player *ActivePlayer) RespondsFor(responseId uuid.UUID) bool {
 	return player.ResponseId == responseId
 }
 
-func (player ActivePlayer) start() {
-	go player.listenForPlayerInput()
-	go writeOutput(player.outputChannel, player.connection)
+func (player *ActivePlayer) start(gameId string, opponent string, timeControl string, rated bool, over <-chan bool) {
+	if player.connection == nil {
+		go player.discardOutput()
+	} else {
+		go player.listenForPlayerInput(over)
+		go player.writeOutput(over)
+	}
 
 	fmt.Println("Starting player for side: " + player.side)
-	err := player.notifyOfGameStart()
+	err := player.notifyOfGameStart(gameId, opponent, timeControl, rated)
 	if err != nil {
-		panic(err.Error())
+		fmt.Printf("Failed to tell %s the game has started: %s\n", player.name, err.Error())
 	}
 }
 
-func NewActivePlayer(side core.Player, connection net.Conn, commandChannel chan<- InfrastructureCommand) *ActivePlayer {
+func NewActivePlayer(side core.Player, playerConnection PlayerConnection, commandChannel chan<- InfrastructureCommand) *ActivePlayer {
 	return &ActivePlayer{
 		side:           side,
-		connection:     connection,
+		name:           playerConnection.Name,
+		connection:     playerConnection.Connection,
+		reader:         playerConnection.Reader,
 		commandChannel: commandChannel,
 		ResponseId:     uuid.New(),
 		outputChannel:  make(chan string),
+		gone:           make(chan bool),
 	}
 }
@@ -0,0 +1,132 @@
+package tcpimpl
+
+import (
+	"fmt"
+	"io"
+	"reversi/core"
+	"reversi/engine"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const computerThinkTime = time.Second
+
+// ComputerFactory makes the player for a new game against the computer.
+type ComputerFactory func() (engine.Player, error)
+
+func computerName(name string) string {
+	return "Computer (" + name + ")"
+}
+
+// builtInComputers are the computers every lobby offers, one for each
+// strategy and one for each difficulty level.
+func builtInComputers() map[string]ComputerFactory {
+	computers := make(map[string]ComputerFactory)
+	for _, strategy := range engine.Strategies {
+		strategy := strategy
+		computers[strings.ToLower(string(strategy))] = func() (engine.Player, error) {
+			return engine.NewPlayer(strategy, 0)
+		}
+	}
+	for _, level := range engine.Levels {
+		level := level
+		computers[level.Name] = func() (engine.Player, error) {
+			return engine.NewLevelPlayer(level, 0), nil
+		}
+	}
+
+	return computers
+}
+
+// computerOpponent plays a seat the server fills itself. It follows the game
+// through the game's own state updates and submits its moves on the same
+// command channel as the connected player.
+type computerOpponent struct {
+	seat      *ActivePlayer
+	player    engine.Player
+	thinkTime time.Duration
+	over      <-chan bool
+}
+
+func (computer *computerOpponent) StateUpdated(gameState core.GameState) {
+	if gameState.IsOver() || gameState.PlayerTurn != computer.seat.side {
+		return
+	}
+
+	// The update arrives while the game is still handling the last command,
+	// so the move is picked and submitted separately.
+	go computer.move(gameState.Copy())
+}
+
+func (computer *computerOpponent) move(gameState core.GameState) {
+	seat := computer.seat
+
+	result, err := computer.player.BestMove(gameState, engine.Limits{Time: computer.thinkTime})
+	if err != nil {
+		fmt.Printf("%s failed to pick a move: %s\n", seat.name, err.Error())
+		seat.submit(InfrastructureCommand{ResponseId: seat.ResponseId, Forfeit: true}, computer.over)
+		return
+	}
+
+	seat.submit(InfrastructureCommand{
+		ResponseId:  seat.ResponseId,
+		CoreCommand: core.NewMoveCommand(seat.side, result.Move),
+	}, computer.over)
+}
+
+// release closes a player that holds on to something, such as an engine
+// process, once the game is over.
+func (computer *computerOpponent) release() {
+	<-computer.over
+
+	closer, holds := computer.player.(io.Closer)
+	if holds {
+		closer.Close()
+	}
+}
+
+func newComputerSeat(side core.Player, name string, commandChannel chan<- InfrastructureCommand) *ActivePlayer {
+	return &ActivePlayer{
+		side:           side,
+		name:           computerName(name),
+		commandChannel: commandChannel,
+		ResponseId:     uuid.New(),
+		outputChannel:  make(chan string),
+		gone:           make(chan bool),
+	}
+}
+
+// NewComputerGame seats the player on the given side and lets the computer
+// play the other one. A computer that is also an event consumer is sent every
+// event of the game. Games against the computer are not rated.
+func NewComputerGame(playerConnection PlayerConnection, side core.Player, name string, player engine.Player, timeControl string, results GameResultConsumer) ActiveGame {
+	gameCommandChannel := make(chan InfrastructureCommand)
+
+	human := NewActivePlayer(side, playerConnection, gameCommandChannel)
+	computer := newComputerSeat(side.Opposite(), name, gameCommandChannel)
+
+	blackGamePlayer, whiteGamePlayer := human, computer
+	if side == core.WHITE {
+		blackGamePlayer, whiteGamePlayer = computer, human
+	}
+
+	game := newActiveGame(blackGamePlayer, whiteGamePlayer, gameCommandChannel, timeControl, false, results)
+
+	follower, follows := player.(core.EventConsumer)
+	if follows {
+		game.followers = append(game.followers, follower)
+	}
+
+	opponent := &computerOpponent{
+		seat:      computer,
+		player:    player,
+		thinkTime: computerThinkTime,
+		over:      game.over,
+	}
+	game.mirror.Register(opponent)
+	go opponent.release()
+
+	return game
+}
@@ -0,0 +1,178 @@
+package tcpimpl_test
+
+import (
+	"encoding/json"
+	"reversi/core"
+	"reversi/engine"
+	"reversi/records"
+	"reversi/tcpimpl"
+	"strings"
+	"testing"
+	"time"
+)
+
+type recordingResults struct {
+	records chan records.GameRecord
+}
+
+func (results recordingResults) GameFinished(record records.GameRecord) tcpimpl.GameOver {
+	results.records <- record
+	return tcpimpl.GameOver{GameId: record.GameId, Winner: record.Winner}
+}
+
+type latestState struct {
+	state core.GameState
+}
+
+func (latest *latestState) StateUpdated(gameState core.GameState) {
+	latest.state = gameState
+}
+
+// playOut answers every turn of side with any legal move until the game is
+// over.
+func (client *testClient) playOut(t *testing.T, side core.Player) {
+	latest := &latestState{}
+	mirror := core.NewGameEventAggregator()
+	mirror.Register(latest)
+
+	for {
+		select {
+		case line, open := <-client.lines:
+			if !open {
+				t.Fatal("The connection closed before the game was over")
+			}
+
+			message := struct {
+				EventType        core.EventType
+				NotificationType tcpimpl.NotificationType
+				Data             json.RawMessage
+			}{}
+			if json.Unmarshal([]byte(line), &message) != nil {
+				t.Fatalf("Unexpected message %s", line)
+			}
+
+			switch {
+			case message.NotificationType == tcpimpl.GAME_OVER:
+				return
+			case message.EventType == core.INITILIZED:
+				mirror.SendEvent(core.NewInitializedEvent())
+			case message.EventType == core.MOVED:
+				move := core.Coordinate{}
+				json.Unmarshal(message.Data, &move)
+				mirror.SendEvent(core.NewMoveEvent(move))
+			default:
+				continue
+			}
+
+			if latest.state.IsOver() || latest.state.PlayerTurn != side {
+				continue
+			}
+			for move := range latest.state.MoveOptions() {
+				client.send(t, move)
+				break
+			}
+		case <-time.After(readTimeout):
+			t.Fatal("Timed out waiting for the computer")
+		}
+	}
+}
+
+func Test_ComputerGame_isPlayedToTheEndAndNotRated(t *testing.T) {
+	for _, side := range []core.Player{core.BLACK, core.WHITE} {
+		connection, client := newTestClient(t, "ada")
+		results := recordingResults{records: make(chan records.GameRecord, 1)}
+
+		player, _ := engine.NewPlayer(engine.RANDOM, 1)
+		game := tcpimpl.NewComputerGame(connection, side, "random", player, "", results)
+		game.Start()
+
+		started := client.expect(t, "Opponent")
+		if !strings.Contains(started, "Computer (random)") {
+			t.Errorf("The player should be told they are playing the computer: %s", started)
+		}
+		if !strings.Contains(started, `"Rated":false`) {
+			t.Errorf("The player should be told the game is not rated: %s", started)
+		}
+
+		client.playOut(t, side)
+		client.expectClosed(t)
+
+		record := <-results.records
+		if record.Rated {
+			t.Error("Games against the computer should not be rated")
+		}
+		if len(record.Moves) < 9 || record.Forfeit != "" {
+			t.Errorf("Expected the game to be played out, instead it ended after %d moves", len(record.Moves))
+		}
+
+		names := map[core.Player]string{core.BLACK: record.Black, core.WHITE: record.White}
+		if names[side] != "ada" || names[side.Opposite()] != "Computer (random)" {
+			t.Errorf("Expected ada to play %s against the computer, instead got %s and %s", side, record.Black, record.White)
+		}
+	}
+}
+
+func Test_ComputerHandshake_startsAGameWithoutWaiting(t *testing.T) {
+	lobby := newTestLobby(t)
+
+	client := admit(t, lobby, `{"Mode":"PLAY","Name":"ada","Password":"secret","Register":true,"Computer":"greedy"}`)
+	client.expect(t, string(tcpimpl.LOGGED_IN))
+	started := client.expect(t, "Opponent")
+	if !strings.Contains(started, "Computer (greedy)") {
+		t.Errorf("Expected a game against the greedy computer, instead got %s", started)
+	}
+	client.expect(t, string(core.INITILIZED))
+}
+
+func Test_ComputerHandshake_withALevel_playsAtThatLevel(t *testing.T) {
+	lobby := newTestLobby(t)
+
+	client := admit(t, lobby, `{"Mode":"PLAY","Name":"ada","Password":"secret","Register":true,"Computer":"Novice"}`)
+	client.expect(t, string(tcpimpl.LOGGED_IN))
+	started := client.expect(t, "Opponent")
+	if !strings.Contains(started, "Computer (novice)") {
+		t.Errorf("Expected a game against the novice computer, instead got %s", started)
+	}
+	client.expect(t, string(core.INITILIZED))
+}
+
+func Test_ComputerHandshake_forAnUnknownComputer_isRejected(t *testing.T) {
+	lobby := newTestLobby(t)
+
+	client := admit(t, lobby, `{"Mode":"PLAY","Name":"ada","Password":"secret","Register":true,"Computer":"chess"}`)
+	client.expect(t, "No computer called chess")
+	client.expectClosed(t)
+}
+
+// followingComputer plays greedily and passes on the events it is sent.
+type followingComputer struct {
+	engine.Player
+	events chan core.Event
+}
+
+func (computer *followingComputer) SendEvent(event core.Event) {
+	computer.events <- event
+}
+
+func Test_AddedComputer_isOfferedAndFollowsTheGame(t *testing.T) {
+	lobby := newTestLobby(t)
+
+	greedy, _ := engine.NewPlayer(engine.GREEDY, 1)
+	computer := &followingComputer{Player: greedy, events: make(chan core.Event, 64)}
+	lobby.AddComputer("Edax", func() (engine.Player, error) { return computer, nil })
+
+	client := admit(t, lobby, `{"Mode":"PLAY","Name":"ada","Password":"secret","Register":true,"Computer":"edax"}`)
+	started := client.expect(t, "Opponent")
+	if !strings.Contains(started, "Computer (edax)") {
+		t.Errorf("Expected a game against the added computer, instead got %s", started)
+	}
+
+	select {
+	case event := <-computer.events:
+		if event.EventType != core.INITILIZED {
+			t.Errorf("Expected the computer to be sent the start of the game first, instead got %s", event.EventType)
+		}
+	case <-time.After(readTimeout):
+		t.Error("The computer should be sent the events of the game")
+	}
+}
@@ -0,0 +1,54 @@
+package tcpimpl
+
+import (
+	"reversi/core"
+	"sync"
+	"time"
+)
+
+type gameClock struct {
+	lock      sync.Mutex
+	elapsed   map[core.Player]time.Duration
+	running   core.Player
+	startedAt time.Time
+	started   bool
+}
+
+func (clock *gameClock) StateUpdated(gameState core.GameState) {
+	clock.lock.Lock()
+	defer clock.lock.Unlock()
+
+	now := time.Now()
+	if clock.started {
+		clock.elapsed[clock.running] = clock.elapsed[clock.running] + now.Sub(clock.startedAt)
+	}
+
+	clock.running = gameState.PlayerTurn
+	clock.startedAt = now
+	clock.started = true
+}
+
+func (clock *gameClock) readings() Clocks {
+	clock.lock.Lock()
+	defer clock.lock.Unlock()
+
+	result := make(Clocks)
+	for side, elapsed := range clock.elapsed {
+		result[side] = elapsed
+	}
+
+	if clock.started {
+		result[clock.running] = result[clock.running] + time.Since(clock.startedAt)
+	}
+
+	return result
+}
+
+func newGameClock() *gameClock {
+	return &gameClock{
+		elapsed: map[core.Player]time.Duration{
+			core.BLACK: 0,
+			core.WHITE: 0,
+		},
+	}
+}
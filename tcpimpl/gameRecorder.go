@@ -0,0 +1,89 @@
+package tcpimpl
+
+import (
+	"reversi/core"
+	"reversi/ratings"
+	"reversi/records"
+	"time"
+)
+
+type GameResultConsumer interface {
+	GameFinished(record records.GameRecord) GameOver
+}
+
+type gameRecorder struct {
+	record   records.GameRecord
+	finished bool
+}
+
+func (recorder *gameRecorder) SendEvent(event core.Event) {
+	if event.EventType == core.MOVED {
+		recorder.record.Moves = append(recorder.record.Moves, event.Data.(core.Coordinate))
+	}
+}
+
+func (recorder *gameRecorder) StateUpdated(gameState core.GameState) {
+	if recorder.finished {
+		return
+	}
+
+	recorder.record.BlackDiscs = gameState.DiscCount(core.BLACK)
+	recorder.record.WhiteDiscs = gameState.DiscCount(core.WHITE)
+	if !gameState.IsOver() {
+		return
+	}
+
+	winner, _ := gameState.Winner()
+
+	recorder.record.Winner = winner
+	recorder.record.FinishedAt = time.Now()
+	recorder.finished = true
+}
+
+func (recorder *gameRecorder) forfeit(side core.Player) {
+	if recorder.finished {
+		return
+	}
+
+	recorder.record.Winner = side.Opposite()
+	recorder.record.Forfeit = side
+	recorder.record.FinishedAt = time.Now()
+	recorder.finished = true
+}
+
+func newGameRecorder(gameId string, players []*ActivePlayer, timeControl string, rated bool) *gameRecorder {
+	record := records.GameRecord{
+		GameId:      gameId,
+		Moves:       []core.Coordinate{},
+		TimeControl: timeControl,
+		Rated:       rated,
+		StartedAt:   time.Now(),
+	}
+
+	for _, player := range players {
+		if player.side == core.BLACK {
+			record.Black = player.name
+		} else {
+			record.White = player.name
+		}
+	}
+
+	return &gameRecorder{record: record}
+}
+
+func newGameOver(record records.GameRecord, ratingChanges map[core.Player]ratings.RatingChange) GameOver {
+	return GameOver{
+		GameId: record.GameId,
+		Players: map[core.Player]string{
+			core.BLACK: record.Black,
+			core.WHITE: record.White,
+		},
+		Discs: map[core.Player]int{
+			core.BLACK: record.BlackDiscs,
+			core.WHITE: record.WhiteDiscs,
+		},
+		Winner:        record.Winner,
+		Forfeit:       record.Forfeit,
+		RatingChanges: ratingChanges,
+	}
+}
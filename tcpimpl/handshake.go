@@ -0,0 +1,80 @@
+package tcpimpl
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+type ConnectionMode string
+
+const (
+	PLAY        ConnectionMode = "PLAY"
+	WATCH       ConnectionMode = "WATCH"
+	LEADERBOARD ConnectionMode = "LEADERBOARD"
+)
+
+type Handshake struct {
+	Mode        ConnectionMode
+	GameId      string
+	Name        string
+	Password    string
+	Register    bool
+	TimeControl string
+	// Computer asks for a game against the server's computer player with that
+	// name instead of waiting for a person.
+	Computer string
+}
+
+func NewLoginHandshake(name string, password string) Handshake {
+	return Handshake{
+		Mode:     PLAY,
+		Name:     name,
+		Password: password,
+	}
+}
+
+func NewRegisterHandshake(name string, password string) Handshake {
+	return Handshake{
+		Mode:     PLAY,
+		Name:     name,
+		Password: password,
+		Register: true,
+	}
+}
+
+func NewWatchHandshake(gameId string) Handshake {
+	return Handshake{
+		Mode:   WATCH,
+		GameId: gameId,
+	}
+}
+
+func NewLeaderboardHandshake() Handshake {
+	return Handshake{Mode: LEADERBOARD}
+}
+
+func readHandshake(reader *bufio.Reader) (Handshake, error) {
+	handshake := Handshake{}
+
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return handshake, err
+	}
+
+	err = json.Unmarshal([]byte(strings.TrimSpace(line)), &handshake)
+	if err != nil {
+		return handshake, err
+	}
+
+	if handshake.Mode != PLAY && handshake.Mode != WATCH && handshake.Mode != LEADERBOARD {
+		return handshake, errors.New("unknown connection mode: " + string(handshake.Mode))
+	}
+
+	if handshake.Mode == WATCH && handshake.GameId == "" {
+		return handshake, errors.New("a game id is required to watch a game")
+	}
+
+	return handshake, nil
+}
@@ -0,0 +1,83 @@
+package tcpimpl_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reversi/accounts"
+	"reversi/ratings"
+	"reversi/records"
+	"reversi/tcpimpl"
+	"testing"
+)
+
+func newTestLobby(t *testing.T) *tcpimpl.Lobby {
+	directory, err := ioutil.TempDir("", "lobby")
+	if err != nil {
+		t.Fatalf("failed to create a temporary directory: %s", err.Error())
+	}
+	t.Cleanup(func() { os.RemoveAll(directory) })
+
+	users, err := accounts.NewUserStore(filepath.Join(directory, "users.json"))
+	if err != nil {
+		t.Fatalf("failed to create the user store: %s", err.Error())
+	}
+	ratingStore, err := ratings.NewStore(filepath.Join(directory, "ratings.json"))
+	if err != nil {
+		t.Fatalf("failed to create the rating store: %s", err.Error())
+	}
+
+	return tcpimpl.NewLobby(users, records.NewStore(filepath.Join(directory, "games.jsonl")), ratingStore)
+}
+
+func admit(t *testing.T, lobby *tcpimpl.Lobby, handshake string) *testClient {
+	connection, client := newTestClient(t, "")
+	go lobby.Admit(connection.Connection)
+
+	_, err := client.connection.Write([]byte(handshake + "\n"))
+	if err != nil {
+		t.Fatalf("failed to send the handshake: %s", err.Error())
+	}
+
+	return client
+}
+
+func Test_InvalidHandshakes_areRejected(t *testing.T) {
+	lobby := newTestLobby(t)
+
+	handshakes := []string{
+		"not json",
+		`{"Mode":"DANCE"}`,
+		`{"Mode":"WATCH"}`,
+	}
+
+	for _, handshake := range handshakes {
+		client := admit(t, lobby, handshake)
+		client.expect(t, "Invalid handshake")
+		client.expectClosed(t)
+	}
+}
+
+func Test_WatchHandshake_forAnUnknownGame_isRejected(t *testing.T) {
+	lobby := newTestLobby(t)
+
+	client := admit(t, lobby, `{"Mode":"WATCH","GameId":"missing"}`)
+	client.expect(t, "No game found with id missing")
+	client.expectClosed(t)
+}
+
+func Test_LeaderboardHandshake_isAnswered(t *testing.T) {
+	lobby := newTestLobby(t)
+
+	client := admit(t, lobby, `{"Mode":"LEADERBOARD"}`)
+	client.expect(t, string(tcpimpl.STANDINGS))
+	client.expectClosed(t)
+}
+
+func Test_LoginHandshake_withUnknownCredentials_isRejected(t *testing.T) {
+	lobby := newTestLobby(t)
+
+	client := admit(t, lobby, `{"Mode":"PLAY","Name":"ada","Password":"secret"}`)
+	client.expect(t, "Login failed")
+	client.expectClosed(t)
+}
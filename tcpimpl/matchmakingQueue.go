@@ -0,0 +1,148 @@
+package tcpimpl
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	initialRatingWindow = 100.0
+	ratingWindowGrowth  = 10.0
+	maximumRatingWindow = 800.0
+)
+
+type queueEntry struct {
+	connection  PlayerConnection
+	rating      float64
+	timeControl string
+	joinedAt    time.Time
+}
+
+type QueueStatus struct {
+	Position     int
+	Waiting      time.Duration
+	Rating       float64
+	RatingWindow float64
+	TimeControl  string
+}
+
+// MatchmakingQueue pairs waiting players by rating. Each player accepts
+// opponents within a rating window that widens the longer they wait.
+type MatchmakingQueue struct {
+	entries []*queueEntry
+}
+
+type Match struct {
+	Black       PlayerConnection
+	White       PlayerConnection
+	TimeControl string
+}
+
+func ratingWindow(entry *queueEntry, now time.Time) float64 {
+	window := initialRatingWindow + ratingWindowGrowth*now.Sub(entry.joinedAt).Seconds()
+	return math.Min(window, maximumRatingWindow)
+}
+
+func timeControlsMatch(first *queueEntry, second *queueEntry) bool {
+	if first.timeControl == "" || second.timeControl == "" {
+		return true
+	}
+
+	return first.timeControl == second.timeControl
+}
+
+func (queue *MatchmakingQueue) compatible(first *queueEntry, second *queueEntry, now time.Time) bool {
+	if !timeControlsMatch(first, second) {
+		return false
+	}
+
+	// The player who has waited longer decides, so nobody waits forever
+	// because a newcomer is still being picky.
+	window := math.Max(ratingWindow(first, now), ratingWindow(second, now))
+	return math.Abs(first.rating-second.rating) <= window
+}
+
+func (queue *MatchmakingQueue) IsWaiting(name string) bool {
+	for _, entry := range queue.entries {
+		if strings.EqualFold(entry.connection.Name, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (queue *MatchmakingQueue) Add(connection PlayerConnection, rating float64, timeControl string, now time.Time) {
+	queue.entries = append(queue.entries, &queueEntry{
+		connection:  connection,
+		rating:      rating,
+		timeControl: timeControl,
+		joinedAt:    now,
+	})
+}
+
+func (queue *MatchmakingQueue) remove(toRemove *queueEntry) {
+	remaining := queue.entries[:0]
+	for _, entry := range queue.entries {
+		if entry != toRemove {
+			remaining = append(remaining, entry)
+		}
+	}
+	queue.entries = remaining
+}
+
+// NextMatch pairs the longest waiting player that has an acceptable opponent
+// with the closest rated of those opponents. The longer waiting player plays
+// BLACK.
+func (queue *MatchmakingQueue) NextMatch(now time.Time) (Match, bool) {
+	for i, entry := range queue.entries {
+		var closest *queueEntry
+		for _, candidate := range queue.entries[i+1:] {
+			if !queue.compatible(entry, candidate, now) {
+				continue
+			}
+
+			if closest == nil || math.Abs(candidate.rating-entry.rating) < math.Abs(closest.rating-entry.rating) {
+				closest = candidate
+			}
+		}
+
+		if closest != nil {
+			timeControl := entry.timeControl
+			if timeControl == "" {
+				timeControl = closest.timeControl
+			}
+
+			queue.remove(entry)
+			queue.remove(closest)
+
+			return Match{
+				Black:       entry.connection,
+				White:       closest.connection,
+				TimeControl: timeControl,
+			}, true
+		}
+	}
+
+	return Match{}, false
+}
+
+func (queue *MatchmakingQueue) statuses(now time.Time) map[*queueEntry]QueueStatus {
+	result := make(map[*queueEntry]QueueStatus)
+	for i, entry := range queue.entries {
+		result[entry] = QueueStatus{
+			Position:     i + 1,
+			Waiting:      now.Sub(entry.joinedAt),
+			Rating:       entry.rating,
+			RatingWindow: ratingWindow(entry, now),
+			TimeControl:  entry.timeControl,
+		}
+	}
+
+	return result
+}
+
+func NewMatchmakingQueue() *MatchmakingQueue {
+	return &MatchmakingQueue{entries: []*queueEntry{}}
+}
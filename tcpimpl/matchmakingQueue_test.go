@@ -0,0 +1,87 @@
+package tcpimpl_test
+
+import (
+	"reversi/tcpimpl"
+	"testing"
+	"time"
+)
+
+func player(name string) tcpimpl.PlayerConnection {
+	return tcpimpl.PlayerConnection{Name: name}
+}
+
+func Test_PlayersWithCloseRatings_arePaired(t *testing.T) {
+	now := time.Now()
+	queue := tcpimpl.NewMatchmakingQueue()
+
+	queue.Add(player("ada"), 1500, "", now)
+	queue.Add(player("bob"), 1900, "", now)
+	queue.Add(player("cy"), 1550, "", now)
+
+	match, found := queue.NextMatch(now)
+	if !found {
+		t.Fatal("ada and cy should have been paired")
+	}
+	if match.Black.Name != "ada" || match.White.Name != "cy" {
+		t.Errorf("Expected ada to play cy, instead got %s against %s", match.Black.Name, match.White.Name)
+	}
+
+	if !queue.IsWaiting("bob") || queue.IsWaiting("ada") {
+		t.Error("Only bob should still be waiting")
+	}
+}
+
+func Test_RatingWindow_widensWhilePlayersWait(t *testing.T) {
+	joined := time.Now()
+	queue := tcpimpl.NewMatchmakingQueue()
+
+	queue.Add(player("ada"), 1500, "", joined)
+	queue.Add(player("bob"), 1800, "", joined)
+
+	_, found := queue.NextMatch(joined)
+	if found {
+		t.Error("A 300 point gap should be too wide for players who just joined")
+	}
+
+	_, found = queue.NextMatch(joined.Add(time.Minute))
+	if !found {
+		t.Error("After waiting a minute the players should have been paired")
+	}
+}
+
+func Test_ClosestRatedOpponent_isChosen(t *testing.T) {
+	now := time.Now()
+	queue := tcpimpl.NewMatchmakingQueue()
+
+	queue.Add(player("ada"), 1500, "", now)
+	queue.Add(player("bob"), 1580, "", now)
+	queue.Add(player("cy"), 1490, "", now)
+
+	match, _ := queue.NextMatch(now)
+	if match.White.Name != "cy" {
+		t.Errorf("ada should play the closest rated player cy, instead got %s", match.White.Name)
+	}
+}
+
+func Test_TimeControlPreferences_mustMatch(t *testing.T) {
+	now := time.Now()
+	queue := tcpimpl.NewMatchmakingQueue()
+
+	queue.Add(player("ada"), 1500, "5+0", now)
+	queue.Add(player("bob"), 1500, "15+10", now)
+
+	_, found := queue.NextMatch(now.Add(time.Hour))
+	if found {
+		t.Error("Players with different time controls should never be paired")
+	}
+
+	queue.Add(player("cy"), 1500, "", now)
+
+	match, found := queue.NextMatch(now)
+	if !found {
+		t.Fatal("A player without a preference should be paired with anyone")
+	}
+	if match.TimeControl != "5+0" {
+		t.Errorf("The game should use ada's time control, instead got %q", match.TimeControl)
+	}
+}
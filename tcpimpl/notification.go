@@ -0,0 +1,96 @@
+package tcpimpl
+
+import (
+	"encoding/json"
+	"reversi/core"
+	"reversi/ratings"
+	"time"
+)
+
+type NotificationType string
+
+const (
+	SPECTATING NotificationType = "SPECTATING"
+	CLOCKS     NotificationType = "CLOCKS"
+	LOGGED_IN  NotificationType = "LOGGED_IN"
+	GAME_OVER  NotificationType = "GAME_OVER"
+	STANDINGS  NotificationType = "STANDINGS"
+	QUEUED     NotificationType = "QUEUED"
+)
+
+type Notification struct {
+	NotificationType NotificationType
+	Data             interface{}
+}
+
+type Clocks map[core.Player]time.Duration
+
+type Spectating struct {
+	GameId  string
+	Players map[core.Player]string
+	Clocks  Clocks
+}
+
+func newSpectatingNotification(gameId string, players map[core.Player]string, clocks Clocks) Notification {
+	return Notification{
+		NotificationType: SPECTATING,
+		Data: Spectating{
+			GameId:  gameId,
+			Players: players,
+			Clocks:  clocks,
+		},
+	}
+}
+
+func newClocksNotification(clocks Clocks) Notification {
+	return Notification{
+		NotificationType: CLOCKS,
+		Data:             clocks,
+	}
+}
+
+type GameOver struct {
+	GameId        string
+	Players       map[core.Player]string
+	Discs         map[core.Player]int
+	Winner        core.Player
+	Forfeit       core.Player
+	RatingChanges map[core.Player]ratings.RatingChange
+}
+
+func newLoggedInNotification(name string) Notification {
+	return Notification{
+		NotificationType: LOGGED_IN,
+		Data:             name,
+	}
+}
+
+func newGameOverNotification(gameOver GameOver) Notification {
+	return Notification{
+		NotificationType: GAME_OVER,
+		Data:             gameOver,
+	}
+}
+
+func newLeaderboardNotification(standings []ratings.PlayerRating) Notification {
+	return Notification{
+		NotificationType: STANDINGS,
+		Data:             standings,
+	}
+}
+
+func newQueueStatusNotification(status QueueStatus) Notification {
+	return Notification{
+		NotificationType: QUEUED,
+		Data:             status,
+	}
+}
+
+func serialize(value interface{}) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
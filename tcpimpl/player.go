@@ -1,11 +1,12 @@
 package tcpimpl
 
 import (
-	"github.com/google/uuid"
+	"bufio"
 	"net"
 )
 
 type PlayerConnection struct {
 	Connection net.Conn
-	Id         uuid.UUID
+	Reader     *bufio.Reader
+	Name       string
 }
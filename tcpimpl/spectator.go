@@ -0,0 +1,214 @@
+package tcpimpl
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"reversi/core"
+	"sync"
+)
+
+// Spectator queues messages for a watcher. A watcher that falls so far
+// behind that the queue fills up is disconnected, the game does not wait for
+// it.
+type Spectator struct {
+	lock          sync.Mutex
+	connection    PlayerConnection
+	outputChannel chan string
+	done          chan bool
+	closed        bool
+}
+
+func (spectator *Spectator) Notify(message string) {
+	spectator.lock.Lock()
+	defer spectator.lock.Unlock()
+
+	if spectator.closed {
+		return
+	}
+
+	select {
+	case spectator.outputChannel <- message:
+	default:
+		fmt.Println("Spectator is not keeping up, disconnecting")
+		spectator.disconnect()
+	}
+}
+
+// disconnect drops the watcher, it is called with the lock held.
+func (spectator *Spectator) disconnect() {
+	spectator.closed = true
+	close(spectator.done)
+	spectator.connection.Connection.Close()
+}
+
+func (spectator *Spectator) disconnected() bool {
+	select {
+	case <-spectator.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// finish lets the watcher read what is already queued and then closes the
+// connection.
+func (spectator *Spectator) finish() {
+	spectator.lock.Lock()
+	defer spectator.lock.Unlock()
+
+	if spectator.closed {
+		return
+	}
+
+	spectator.closed = true
+	close(spectator.outputChannel)
+}
+
+func (spectator *Spectator) writeOutput() {
+	for message := range spectator.outputChannel {
+		_, err := spectator.connection.Connection.Write([]byte(message + "\n"))
+		if err != nil {
+			fmt.Printf("Spectator disconnected: %s\n", err.Error())
+
+			spectator.lock.Lock()
+			if !spectator.closed {
+				spectator.disconnect()
+			}
+			spectator.lock.Unlock()
+			return
+		}
+	}
+
+	spectator.connection.Connection.Close()
+}
+
+func (spectator *Spectator) ignoreInput() {
+	for {
+		_, err := spectator.connection.Reader.ReadString('\n')
+		if err != nil {
+			spectator.connection.Connection.Close()
+			return
+		}
+
+		spectator.Notify("Spectators cannot submit commands")
+	}
+}
+
+func (spectator *Spectator) start() {
+	go spectator.writeOutput()
+	go spectator.ignoreInput()
+}
+
+func NewSpectator(connection PlayerConnection) *Spectator {
+	return &Spectator{
+		connection:    connection,
+		outputChannel: make(chan string, 64),
+		done:          make(chan bool),
+	}
+}
+
+type spectatorGallery struct {
+	lock       sync.Mutex
+	gameId     string
+	players    map[core.Player]string
+	history    []string
+	spectators []*Spectator
+	clock      *gameClock
+	over       bool
+}
+
+func (gallery *spectatorGallery) welcome() (string, error) {
+	return serialize(newSpectatingNotification(gallery.gameId, gallery.players, gallery.clock.readings()))
+}
+
+func (gallery *spectatorGallery) admit(spectator *Spectator) error {
+	gallery.lock.Lock()
+	defer gallery.lock.Unlock()
+
+	if gallery.over {
+		return errors.New("the game is over")
+	}
+
+	welcome, err := gallery.welcome()
+	if err != nil {
+		return err
+	}
+
+	spectator.start()
+	spectator.Notify(welcome)
+	for _, message := range gallery.history {
+		spectator.Notify(message)
+	}
+
+	gallery.spectators = append(gallery.spectators, spectator)
+	return nil
+}
+
+func (gallery *spectatorGallery) broadcast(message string) {
+	stillWatching := gallery.spectators[:0]
+	for _, spectator := range gallery.spectators {
+		if spectator.disconnected() {
+			continue
+		}
+
+		spectator.Notify(message)
+		stillWatching = append(stillWatching, spectator)
+	}
+	gallery.spectators = stillWatching
+}
+
+func (gallery *spectatorGallery) publish(message string) {
+	gallery.lock.Lock()
+	defer gallery.lock.Unlock()
+
+	gallery.history = append(gallery.history, message)
+	gallery.broadcast(message)
+}
+
+func (gallery *spectatorGallery) eventOccurred(message string) {
+	gallery.publish(message)
+
+	clocks, err := serialize(newClocksNotification(gallery.clock.readings()))
+	if err != nil {
+		fmt.Printf("Failed to serialize the clocks: %s\n", err.Error())
+		return
+	}
+
+	gallery.lock.Lock()
+	defer gallery.lock.Unlock()
+	gallery.broadcast(clocks)
+}
+
+// close sends the spectators away once everything published has been
+// written to them.
+func (gallery *spectatorGallery) close() {
+	gallery.lock.Lock()
+	defer gallery.lock.Unlock()
+
+	gallery.over = true
+	for _, spectator := range gallery.spectators {
+		spectator.finish()
+	}
+	gallery.spectators = []*Spectator{}
+}
+
+func newSpectatorGallery(gameId string, players []*ActivePlayer, clock *gameClock) *spectatorGallery {
+	names := make(map[core.Player]string)
+	for _, player := range players {
+		names[player.side] = player.name
+	}
+
+	return &spectatorGallery{
+		gameId:     gameId,
+		players:    names,
+		history:    []string{},
+		spectators: []*Spectator{},
+		clock:      clock,
+	}
+}
+
+func rejectSpectator(connection net.Conn, reason string) {
+	defer connection.Close()
+	message(connection, reason+"\n")
+}
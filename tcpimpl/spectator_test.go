@@ -0,0 +1,244 @@
+package tcpimpl_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"reversi/core"
+	"reversi/records"
+	"reversi/tcpimpl"
+	"strings"
+	"testing"
+	"time"
+)
+
+const readTimeout = 2 * time.Second
+
+type testClient struct {
+	connection net.Conn
+	lines      chan string
+}
+
+func newTestClient(t *testing.T, name string) (tcpimpl.PlayerConnection, *testClient) {
+	server, client := net.Pipe()
+	t.Cleanup(func() { client.Close() })
+
+	testClient := &testClient{connection: client, lines: make(chan string, 1024)}
+	go func() {
+		scanner := bufio.NewScanner(client)
+		for scanner.Scan() {
+			testClient.lines <- scanner.Text()
+		}
+		close(testClient.lines)
+	}()
+
+	return tcpimpl.PlayerConnection{Connection: server, Reader: bufio.NewReader(server), Name: name}, testClient
+}
+
+// expect skips lines until one contains text.
+func (client *testClient) expect(t *testing.T, text string) string {
+	for {
+		select {
+		case line, open := <-client.lines:
+			if !open {
+				t.Fatalf("The connection closed before %q arrived", text)
+			}
+			if strings.Contains(line, text) {
+				return line
+			}
+		case <-time.After(readTimeout):
+			t.Fatalf("Timed out waiting for %q", text)
+		}
+	}
+}
+
+func (client *testClient) expectClosed(t *testing.T) {
+	for {
+		select {
+		case _, open := <-client.lines:
+			if !open {
+				return
+			}
+		case <-time.After(readTimeout):
+			t.Fatal("The connection should have been closed")
+		}
+	}
+}
+
+func (client *testClient) send(t *testing.T, coordinate core.Coordinate) {
+	data, _ := json.Marshal(coordinate)
+	_, err := client.connection.Write(append(data, '\n'))
+	if err != nil {
+		t.Fatalf("failed to send a move: %s", err.Error())
+	}
+}
+
+type testResults struct{}
+
+func (results testResults) GameFinished(record records.GameRecord) tcpimpl.GameOver {
+	return tcpimpl.GameOver{GameId: record.GameId, Winner: record.Winner, Forfeit: record.Forfeit}
+}
+
+type turnTracker struct {
+	turn core.Player
+}
+
+func (tracker *turnTracker) StateUpdated(gameState core.GameState) {
+	tracker.turn = gameState.PlayerTurn
+}
+
+type testGame struct {
+	game    tcpimpl.ActiveGame
+	clients map[core.Player]*testClient
+	mirror  core.StateUpdaterAndEventConsumer
+	tracker *turnTracker
+}
+
+func startTestGame(t *testing.T) *testGame {
+	black, blackClient := newTestClient(t, "ada")
+	white, whiteClient := newTestClient(t, "bob")
+
+	game := tcpimpl.NewActiveGame(tcpimpl.Match{Black: black, White: white}, false, testResults{})
+	game.Start()
+
+	tracker := &turnTracker{}
+	mirror := core.NewGameEventAggregator()
+	mirror.Register(tracker)
+	mirror.SendEvent(core.NewInitializedEvent())
+
+	blackClient.expect(t, string(core.INITILIZED))
+	whiteClient.expect(t, string(core.INITILIZED))
+
+	return &testGame{
+		game:    game,
+		clients: map[core.Player]*testClient{core.BLACK: blackClient, core.WHITE: whiteClient},
+		mirror:  mirror,
+		tracker: tracker,
+	}
+}
+
+func (game *testGame) play(t *testing.T, moves []core.Coordinate) {
+	for _, move := range moves {
+		game.clients[game.tracker.turn].send(t, move)
+		game.clients[core.BLACK].expect(t, string(core.MOVED))
+		game.clients[core.WHITE].expect(t, string(core.MOVED))
+		game.mirror.SendEvent(core.NewMoveEvent(move))
+	}
+}
+
+func (game *testGame) watch(t *testing.T) *testClient {
+	connection, client := newTestClient(t, "")
+
+	err := game.game.Watch(tcpimpl.NewSpectator(connection))
+	if err != nil {
+		t.Fatalf("Expected to be able to watch, instead got %s", err.Error())
+	}
+
+	return client
+}
+
+var wipeout = []core.Coordinate{
+	{X: 2, Y: 4}, {X: 2, Y: 3}, {X: 1, Y: 2}, {X: 1, Y: 5}, {X: 1, Y: 4},
+	{X: 2, Y: 5}, {X: 4, Y: 2}, {X: 1, Y: 3}, {X: 1, Y: 6},
+}
+
+var longGame = []core.Coordinate{
+	{X: 2, Y: 4}, {X: 2, Y: 5}, {X: 2, Y: 6}, {X: 1, Y: 4}, {X: 0, Y: 4}, {X: 4, Y: 5},
+	{X: 5, Y: 2}, {X: 4, Y: 2}, {X: 3, Y: 2}, {X: 2, Y: 1}, {X: 3, Y: 1}, {X: 4, Y: 1},
+	{X: 3, Y: 0}, {X: 1, Y: 5}, {X: 4, Y: 6}, {X: 2, Y: 7}, {X: 3, Y: 6}, {X: 1, Y: 3},
+	{X: 0, Y: 5}, {X: 3, Y: 5}, {X: 0, Y: 2}, {X: 1, Y: 2}, {X: 2, Y: 3}, {X: 2, Y: 2},
+	{X: 3, Y: 7}, {X: 0, Y: 3}, {X: 2, Y: 0}, {X: 6, Y: 2}, {X: 7, Y: 2}, {X: 5, Y: 3},
+	{X: 6, Y: 4}, {X: 5, Y: 4}, {X: 4, Y: 7}, {X: 7, Y: 4}, {X: 7, Y: 5}, {X: 5, Y: 7},
+	{X: 7, Y: 3}, {X: 6, Y: 3}, {X: 5, Y: 6}, {X: 5, Y: 5},
+}
+
+func Test_SpectatorJoiningMidGame_receivesTheHistory(t *testing.T) {
+	game := startTestGame(t)
+	game.play(t, wipeout[:2])
+
+	spectator := game.watch(t)
+
+	welcome := spectator.expect(t, string(tcpimpl.SPECTATING))
+	if !strings.Contains(welcome, "ada") || !strings.Contains(welcome, "bob") {
+		t.Errorf("The welcome should name both players: %s", welcome)
+	}
+
+	spectator.expect(t, string(core.INITILIZED))
+	first := spectator.expect(t, string(core.MOVED))
+	second := spectator.expect(t, string(core.MOVED))
+	if !strings.Contains(first, `"X":2,"Y":4`) || !strings.Contains(second, `"X":2,"Y":3`) {
+		t.Errorf("Expected the moves so far in order, instead got %s and %s", first, second)
+	}
+
+	game.play(t, wipeout[2:3])
+	third := spectator.expect(t, string(core.MOVED))
+	if !strings.Contains(third, `"X":1,"Y":2`) {
+		t.Errorf("Expected the next move to follow the history, instead got %s", third)
+	}
+}
+
+func Test_SpectatorCommands_areRejected(t *testing.T) {
+	game := startTestGame(t)
+	spectator := game.watch(t)
+	spectator.expect(t, string(core.INITILIZED))
+
+	spectator.send(t, wipeout[0])
+	spectator.expect(t, "Spectators cannot submit commands")
+
+	game.play(t, wipeout[:1])
+}
+
+func Test_SpectatorThatStopsReading_doesNotHoldUpTheGame(t *testing.T) {
+	game := startTestGame(t)
+
+	server, client := net.Pipe()
+	defer client.Close()
+	err := game.game.Watch(tcpimpl.NewSpectator(tcpimpl.PlayerConnection{Connection: server, Reader: bufio.NewReader(server)}))
+	if err != nil {
+		t.Fatalf("Expected to be able to watch, instead got %s", err.Error())
+	}
+
+	game.play(t, longGame)
+}
+
+func Test_Spectators_areDisconnectedWhenTheGameEnds(t *testing.T) {
+	game := startTestGame(t)
+	spectator := game.watch(t)
+
+	game.play(t, wipeout)
+
+	spectator.expect(t, string(tcpimpl.GAME_OVER))
+	spectator.expectClosed(t)
+
+	connection, _ := newTestClient(t, "")
+	if game.game.Watch(tcpimpl.NewSpectator(connection)) == nil {
+		t.Error("A finished game should not take new spectators")
+	}
+}
+
+func Test_Clocks_chargeThinkingTimeToTheSideToMove(t *testing.T) {
+	game := startTestGame(t)
+	spectator := game.watch(t)
+	spectator.expect(t, string(core.INITILIZED))
+
+	time.Sleep(50 * time.Millisecond)
+	game.play(t, wipeout[:1])
+
+	spectator.expect(t, string(core.MOVED))
+	line := spectator.expect(t, string(tcpimpl.CLOCKS))
+
+	notification := struct{ Data tcpimpl.Clocks }{}
+	err := json.Unmarshal([]byte(line), &notification)
+	if err != nil {
+		t.Fatalf("failed to read the clocks: %s", err.Error())
+	}
+
+	black := notification.Data[core.BLACK]
+	white := notification.Data[core.WHITE]
+	if black < 50*time.Millisecond {
+		t.Errorf("BLACK thought for at least 50ms, instead the clock shows %s", black)
+	}
+	if white >= black {
+		t.Errorf("WHITE has only just started thinking, instead the clock shows %s against %s", white, black)
+	}
+}
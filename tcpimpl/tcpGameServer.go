@@ -1,65 +1,292 @@
 package tcpimpl
 
 import (
+	"bufio"
 	"fmt"
 	"net"
+	"reversi/accounts"
+	"reversi/core"
+	"reversi/ratings"
+	"reversi/records"
+	"strings"
+	"sync"
+	"time"
+)
+
+func message(conn net.Conn, message string) {
+	_, err := conn.Write([]byte(message))
+	if err != nil {
+		fmt.Printf("failed to send message: %s\n", err.Error())
+	}
+}
 
-	"github.com/google/uuid"
+const (
+	leaderboardSize     = 20
+	queueStatusInterval = 5 * time.Second
+	queueWriteTimeout   = 5 * time.Second
 )
 
-type PendingGame struct {
-	players []PlayerConnection
+type Lobby struct {
+	lock      sync.Mutex
+	queue     *MatchmakingQueue
+	games     map[string]ActiveGame
+	computers map[string]ComputerFactory
+	users     *accounts.UserStore
+	records   *records.Store
+	ratings   *ratings.Store
 }
 
-func (pending *PendingGame) addPlayer(connection net.Conn) error {
-	if len(pending.players) < 2 {
-		uuid, err := uuid.NewUUID()
+func (lobby *Lobby) startMatches(now time.Time) {
+	for {
+		match, found := lobby.queue.NextMatch(now)
+		if !found {
+			return
+		}
+
+		activeGame := NewActiveGame(match, true, lobby)
+		lobby.games[activeGame.Id()] = activeGame
+
+		go activeGame.Start()
+	}
+}
+
+type queueUpdate struct {
+	entry   *queueEntry
+	message string
+}
+
+// queueUpdates is called with the lock held, the updates are sent once it
+// has been released so a slow client cannot hold up the lobby.
+func (lobby *Lobby) queueUpdates(now time.Time) []queueUpdate {
+	updates := []queueUpdate{}
+	for entry, status := range lobby.queue.statuses(now) {
+		update, err := serialize(newQueueStatusNotification(status))
 		if err != nil {
-			return err
+			fmt.Printf("failed to serialize the queue status: %s\n", err.Error())
+			continue
 		}
 
-		playerConnection := PlayerConnection{
-			Connection: connection,
-			Id:         uuid,
+		updates = append(updates, queueUpdate{entry: entry, message: update})
+	}
+
+	return updates
+}
+
+func (lobby *Lobby) sendQueueStatuses(updates []queueUpdate) {
+	for _, update := range updates {
+		connection := update.entry.connection.Connection
+
+		connection.SetWriteDeadline(time.Now().Add(queueWriteTimeout))
+		_, err := connection.Write([]byte(update.message + "\n"))
+		connection.SetWriteDeadline(time.Time{})
+
+		if err != nil {
+			fmt.Printf("%s left the queue: %s\n", update.entry.connection.Name, err.Error())
+			connection.Close()
+
+			lobby.lock.Lock()
+			lobby.queue.remove(update.entry)
+			lobby.lock.Unlock()
 		}
-		pending.players = append(pending.players, playerConnection)
 	}
+}
+
+func (lobby *Lobby) matchmake() {
+	lobby.lock.Lock()
+	now := time.Now()
+	lobby.startMatches(now)
+	updates := lobby.queueUpdates(now)
+	lobby.lock.Unlock()
 
-	return nil
+	lobby.sendQueueStatuses(updates)
 }
 
-func (pending PendingGame) IsFull() bool {
-	return len(pending.players) == 2
+func (lobby *Lobby) refreshQueue(interval time.Duration) {
+	for {
+		time.Sleep(interval)
+		lobby.matchmake()
+	}
 }
 
-func (pending PendingGame) stillAcceptingPlayers() bool {
-	return len(pending.players) < 2
+func (lobby *Lobby) seat(playerConnection PlayerConnection, timeControl string) {
+	lobby.lock.Lock()
+	if lobby.queue.IsWaiting(playerConnection.Name) {
+		lobby.lock.Unlock()
+
+		defer playerConnection.Connection.Close()
+		message(playerConnection.Connection, playerConnection.Name+" is already waiting for a game\n")
+		return
+	}
+
+	rating := lobby.ratings.Get(playerConnection.Name).Rating.Rating
+	lobby.queue.Add(playerConnection, rating, timeControl, time.Now())
+	lobby.lock.Unlock()
+
+	lobby.matchmake()
 }
 
-func message(conn net.Conn, message string) {
-	_, err := conn.Write([]byte(message))
+// AddComputer offers another computer opponent under the name, replacing any
+// computer already called that.
+func (lobby *Lobby) AddComputer(name string, factory ComputerFactory) {
+	lobby.lock.Lock()
+	defer lobby.lock.Unlock()
+
+	lobby.computers[strings.ToLower(name)] = factory
+}
+
+// playComputer starts a game against the computer straight away, the player
+// is given either side.
+func (lobby *Lobby) playComputer(playerConnection PlayerConnection, name string, timeControl string) {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	lobby.lock.Lock()
+	factory, found := lobby.computers[name]
+	lobby.lock.Unlock()
+
+	if !found {
+		defer playerConnection.Connection.Close()
+		message(playerConnection.Connection, "No computer called "+name+"\n")
+		return
+	}
+
+	player, err := factory()
 	if err != nil {
-		fmt.Errorf("failed to send message: %s", err.Error())
+		defer playerConnection.Connection.Close()
+		message(playerConnection.Connection, "Unable to start a game against the computer\n")
+		fmt.Printf("failed to start the computer %s: %s\n", name, err.Error())
+		return
 	}
+
+	side := core.BLACK
+	if time.Now().UnixNano()%2 == 1 {
+		side = core.WHITE
+	}
+
+	activeGame := NewComputerGame(playerConnection, side, name, player, timeControl, lobby)
+
+	lobby.lock.Lock()
+	lobby.games[activeGame.Id()] = activeGame
+	lobby.lock.Unlock()
+
+	go activeGame.Start()
 }
 
-func (pendingGame *PendingGame) AddPlayer(playerConnection net.Conn) {
-	if pendingGame.stillAcceptingPlayers() {
-		addPlayerErr := pendingGame.addPlayer(playerConnection)
-		if addPlayerErr != nil {
-			defer playerConnection.Close()
-			message(playerConnection, "failed to generate an id")
-			fmt.Errorf("failed to add the player to a pending game: %s", addPlayerErr.Error())
-		}
-	} else {
-		defer playerConnection.Close()
-		message(playerConnection, "Maximum players reached")
+func (lobby *Lobby) watch(playerConnection PlayerConnection, gameId string) {
+	lobby.lock.Lock()
+	activeGame, found := lobby.games[gameId]
+	lobby.lock.Unlock()
+
+	if !found {
+		rejectSpectator(playerConnection.Connection, "No game found with id "+gameId)
+		return
+	}
+
+	err := activeGame.Watch(NewSpectator(playerConnection))
+	if err != nil {
+		rejectSpectator(playerConnection.Connection, "Unable to watch game "+gameId)
+		fmt.Printf("failed to add a spectator to game %s: %s\n", gameId, err.Error())
+	}
+}
+
+func (lobby *Lobby) logIn(handshake Handshake) (accounts.User, error) {
+	if handshake.Register {
+		return lobby.users.Register(handshake.Name, handshake.Password)
 	}
+
+	return lobby.users.Authenticate(handshake.Name, handshake.Password)
 }
 
-func NewPendingGame() PendingGame {
-	var players []PlayerConnection
-	return PendingGame{
-		players: players,
+func (lobby *Lobby) GameFinished(record records.GameRecord) GameOver {
+	lobby.lock.Lock()
+	delete(lobby.games, record.GameId)
+	lobby.lock.Unlock()
+
+	err := lobby.records.Append(record)
+	if err != nil {
+		fmt.Printf("failed to record the result of game %s: %s\n", record.GameId, err.Error())
 	}
+
+	ratingChanges, err := lobby.ratings.RecordGame(record)
+	if err != nil {
+		fmt.Printf("failed to update the ratings for game %s: %s\n", record.GameId, err.Error())
+	}
+
+	return newGameOver(record, ratingChanges)
+}
+
+func (lobby *Lobby) showLeaderboard(connection net.Conn) {
+	defer connection.Close()
+
+	standings, err := serialize(newLeaderboardNotification(lobby.ratings.Leaderboard(leaderboardSize)))
+	if err != nil {
+		fmt.Printf("failed to serialize the leaderboard: %s\n", err.Error())
+		return
+	}
+
+	message(connection, standings+"\n")
+}
+
+func (lobby *Lobby) Admit(connection net.Conn) {
+	reader := bufio.NewReader(connection)
+
+	handshake, err := readHandshake(reader)
+	if err != nil {
+		defer connection.Close()
+		message(connection, "Invalid handshake\n")
+		fmt.Printf("failed to read the handshake: %s\n", err.Error())
+		return
+	}
+
+	playerConnection := PlayerConnection{
+		Connection: connection,
+		Reader:     reader,
+	}
+
+	if handshake.Mode == WATCH {
+		lobby.watch(playerConnection, handshake.GameId)
+		return
+	}
+
+	if handshake.Mode == LEADERBOARD {
+		lobby.showLeaderboard(connection)
+		return
+	}
+
+	user, err := lobby.logIn(handshake)
+	if err != nil {
+		defer connection.Close()
+		message(connection, "Login failed: "+err.Error()+"\n")
+		return
+	}
+
+	loggedIn, err := serialize(newLoggedInNotification(user.Name))
+	if err != nil {
+		defer connection.Close()
+		fmt.Printf("failed to serialize the login notification: %s\n", err.Error())
+		return
+	}
+	message(connection, loggedIn+"\n")
+
+	playerConnection.Name = user.Name
+	if handshake.Computer != "" {
+		lobby.playComputer(playerConnection, handshake.Computer, handshake.TimeControl)
+		return
+	}
+
+	lobby.seat(playerConnection, handshake.TimeControl)
+}
+
+func NewLobby(users *accounts.UserStore, records *records.Store, ratings *ratings.Store) *Lobby {
+	lobby := &Lobby{
+		queue:     NewMatchmakingQueue(),
+		games:     make(map[string]ActiveGame),
+		computers: builtInComputers(),
+		users:     users,
+		records:   records,
+		ratings:   ratings,
+	}
+
+	go lobby.refreshQueue(queueStatusInterval)
+
+	return lobby
 }
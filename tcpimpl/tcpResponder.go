@@ -40,7 +40,7 @@ type infraResponder struct {
 func (responder *infraResponder) respond(message string) {
 	for _, player := range responder.players {
 		if player.ResponseId == responder.responseId {
-			player.Notify(message + " -> " + responder.responseId.String())
+			player.Notify(message)
 		}
 	}
 }
@@ -63,6 +63,7 @@ func (responder *infraResponder) notifyInactivePlayer(message string, activePlay
 
 type responderFactory struct {
 	players []*ActivePlayer
+	game    *activeGameImpl
 }
 
 func (factory responderFactory) getInstance(responseId uuid.UUID) core.CommandRejectHandler {
@@ -76,6 +77,7 @@ func (factory responderFactory) getInstance(responseId uuid.UUID) core.CommandRe
 
 type SuccessResponder struct {
 	players []*ActivePlayer
+	game    *activeGameImpl
 }
 
 func (responder SuccessResponder) SendEvent(event core.Event) {
@@ -89,11 +91,14 @@ func (responder SuccessResponder) SendEvent(event core.Event) {
 	for _, player := range responder.players {
 		player.Notify(string(data))
 	}
+
+	responder.game.eventOccurred(event, string(data))
 }
 
 func (factory responderFactory) getSuccessInstance() core.EventConsumer {
 	successResponder := SuccessResponder{
 		players: factory.players,
+		game:    factory.game,
 	}
 
 	return &successResponder
@@ -0,0 +1,100 @@
+package tcpimpl
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+type ClientTLSOptions struct {
+	UseTLS      bool
+	CAFile      string
+	Fingerprint string
+	Insecure    bool
+}
+
+func (options ClientTLSOptions) Enabled() bool {
+	return options.UseTLS || options.CAFile != "" || options.Fingerprint != "" || options.Insecure
+}
+
+func Fingerprint(certificate []byte) string {
+	sum := sha256.Sum256(certificate)
+	return hex.EncodeToString(sum[:])
+}
+
+func normalizeFingerprint(fingerprint string) string {
+	fingerprint = strings.ToLower(strings.TrimSpace(fingerprint))
+	return strings.Replace(fingerprint, ":", "", -1)
+}
+
+func NewServerTLSConfig(certFile string, keyFile string) (*tls.Config, error) {
+	certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+func pinnedCertificateVerifier(fingerprint string) func([][]byte, [][]*x509.Certificate) error {
+	expected := normalizeFingerprint(fingerprint)
+
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return errors.New("the server did not present a certificate")
+		}
+
+		actual := Fingerprint(rawCerts[0])
+		if actual != expected {
+			return fmt.Errorf("the server certificate fingerprint %s does not match the pinned fingerprint", actual)
+		}
+
+		return nil
+	}
+}
+
+var ErrConflictingTLSOptions = errors.New("a certificate authority cannot be combined with a pinned fingerprint or skipping verification")
+
+func NewClientTLSConfig(options ClientTLSOptions) (*tls.Config, error) {
+	// Either of the others turns off the chain verification the certificate
+	// authority is given for.
+	if options.CAFile != "" && (options.Fingerprint != "" || options.Insecure) {
+		return nil, ErrConflictingTLSOptions
+	}
+
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if options.CAFile != "" {
+		pem, err := ioutil.ReadFile(options.CAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("no certificates found in " + options.CAFile)
+		}
+		config.RootCAs = pool
+	}
+
+	// Pinning replaces the chain verification, the fingerprint alone decides
+	// whether the certificate is trusted.
+	if options.Fingerprint != "" {
+		config.InsecureSkipVerify = true
+		config.VerifyPeerCertificate = pinnedCertificateVerifier(options.Fingerprint)
+	} else if options.Insecure {
+		config.InsecureSkipVerify = true
+	}
+
+	return config, nil
+}
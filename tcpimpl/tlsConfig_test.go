@@ -0,0 +1,72 @@
+package tcpimpl_test
+
+import (
+	"reversi/tcpimpl"
+	"strings"
+	"testing"
+)
+
+var certificate = []byte("a certificate as sent by the server")
+
+func verifierFor(t *testing.T, fingerprint string) func([][]byte) error {
+	config, err := tcpimpl.NewClientTLSConfig(tcpimpl.ClientTLSOptions{Fingerprint: fingerprint})
+	if err != nil {
+		t.Fatalf("Expected a configuration, instead got %s", err.Error())
+	}
+	if !config.InsecureSkipVerify || config.VerifyPeerCertificate == nil {
+		t.Fatal("A pinned fingerprint should replace the chain verification")
+	}
+
+	return func(rawCerts [][]byte) error {
+		return config.VerifyPeerCertificate(rawCerts, nil)
+	}
+}
+
+func Test_PinnedFingerprint_acceptsTheMatchingCertificate(t *testing.T) {
+	verify := verifierFor(t, tcpimpl.Fingerprint(certificate))
+
+	err := verify([][]byte{certificate})
+	if err != nil {
+		t.Errorf("The pinned certificate should be accepted: %s", err.Error())
+	}
+}
+
+func Test_PinnedFingerprint_rejectsAnyOtherCertificate(t *testing.T) {
+	verify := verifierFor(t, tcpimpl.Fingerprint(certificate))
+
+	if verify([][]byte{[]byte("another certificate")}) == nil {
+		t.Error("A certificate with a different fingerprint should be rejected")
+	}
+	if verify([][]byte{}) == nil {
+		t.Error("A server without a certificate should be rejected")
+	}
+}
+
+func Test_PinnedFingerprint_ignoresColonsAndCase(t *testing.T) {
+	fingerprint := tcpimpl.Fingerprint(certificate)
+
+	pairs := []string{}
+	for i := 0; i < len(fingerprint); i += 2 {
+		pairs = append(pairs, fingerprint[i:i+2])
+	}
+	written := " " + strings.ToUpper(strings.Join(pairs, ":")) + " "
+
+	err := verifierFor(t, written)([][]byte{certificate})
+	if err != nil {
+		t.Errorf("%s should match the certificate: %s", written, err.Error())
+	}
+}
+
+func Test_CertificateAuthority_cannotBeCombinedWithoutVerification(t *testing.T) {
+	combinations := []tcpimpl.ClientTLSOptions{
+		{CAFile: "ca.pem", Fingerprint: tcpimpl.Fingerprint(certificate)},
+		{CAFile: "ca.pem", Insecure: true},
+	}
+
+	for _, options := range combinations {
+		_, err := tcpimpl.NewClientTLSConfig(options)
+		if err != tcpimpl.ErrConflictingTLSOptions {
+			t.Errorf("Expected ErrConflictingTLSOptions, instead got %v", err)
+		}
+	}
+}